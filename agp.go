@@ -1,232 +1,251 @@
 package agp
 
 import (
-	"errors"
 	"fmt"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"math"
-	"regexp"
 	"strings"
 )
 
 // ParseHexDecode parses a given 256 hex into a Gene object. This combines ParseHex and Decode into a single function.
-func ParseHexDecode(hex string) (Genes, error) {
+func ParseHexDecode(hex string, opts ...DecodeOption) (Genes, error) {
 	gbg, err := ParseHex(hex)
 	if err != nil {
 		return Genes{}, err
 	}
-	return Decode(&gbg)
+	return Decode(&gbg, opts...)
 }
 
 // ParseHexDecode512 parses a given 512 hex into a Gene object. This combines ParseHex512 and Decode512 into a single function.
-func ParseHexDecode512(hex string) (Genes, error) {
+func ParseHexDecode512(hex string, opts ...DecodeOption) (Genes, error) {
 	gbg, err := ParseHex512(hex)
 	if err != nil {
 		return Genes{}, err
 	}
-	return Decode512(&gbg)
+	return Decode512(&gbg, opts...)
 }
 
-// ParseHex divide bits from the 256 hex representation of the string into their respective groups.
-func ParseHex(hex string) (GeneBinGroup, error) {
-	var gbg GeneBinGroup
-	// Convert hex into binary
-	bInt, err := hexutil.DecodeBig("0x" + strings.TrimLeft(hex[2:], "0"))
-	if err != nil {
-		return gbg, err
-	}
-	// Append leading zeroes to fill the 256 bit requirement.
-	bStr := fmt.Sprintf("%0*s", 256, bInt.Text(2))
-	gbg.Class = bStr[0:4]
-	gbg.Region = bStr[8:13]
-	gbg.Tag = bStr[13:18]
-	gbg.BodySkin = bStr[18:22]
-	gbg.Xmas = bStr[22:34]
-	gbg.Pattern = bStr[34:52]
-	gbg.Color = bStr[52:64]
-	gbg.Eyes = bStr[64:96]
-	gbg.Mouth = bStr[96:128]
-	gbg.Ears = bStr[128:160]
-	gbg.Horn = bStr[160:192]
-	gbg.Back = bStr[192:224]
-	gbg.Tail = bStr[224:256]
-	return gbg, nil
-}
-
-// hexToBin converts a given 256 bit hex into binary.
-func hexToBin(hex string) (string, error) {
-	// Remove leading zeroes.
-	p := regexp.MustCompile("^0+")
-	str := p.ReplaceAllString(hex, "")
-	bInt, err := hexutil.DecodeBig("0x" + str)
+// ParseHexDecodeAuto parses a given 256 or 512 hex into a Gene object. The format of the hex is detected using DetectFormat
+// and is returned along with the genes.
+func ParseHexDecodeAuto(hex string, opts ...DecodeOption) (Genes, GeneFormat, error) {
+	format, err := DetectFormat(hex)
 	if err != nil {
-		return "", err
+		return Genes{}, format, err
 	}
-	// Append back the leading zeroes to fill the 256 bits.
-	return fmt.Sprintf("%0*s", 256, bInt.Text(2)), nil
-}
-
-// ParseHex512 divide bits from the 512 hex representation of the string into their respective groups.
-func ParseHex512(hex string) (GeneBinGroup, error) {
-	var gbg GeneBinGroup
-	// Convert first 256 bit hex into binary.
-	bStrL, err := hexToBin(hex[2:][:len(hex[2:])-64])
-	if err != nil {
-		return gbg, err
+	if format == Format512 {
+		genes, err := ParseHexDecode512(hex, opts...)
+		return genes, format, err
 	}
-	// Convert the next 256 bit hex into binary.
-	bStrR, err := hexToBin(hex[2:][len(hex[2:])-64:])
-	if err != nil {
-		return gbg, err
-	}
-	// Merged the converted binaries.
-	bStr := bStrL + bStrR
-	gbg.Class = bStr[0:5]
-	gbg.Region = bStr[22:40]
-	gbg.Tag = bStr[40:55]
-	gbg.BodySkin = bStr[61:65]
-	gbg.Pattern = bStr[65:92]
-	gbg.Color = bStr[92:110]
-	gbg.Eyes = bStr[149:192]
-	gbg.Mouth = bStr[213:256]
-	gbg.Ears = bStr[277:320]
-	gbg.Horn = bStr[341:384]
-	gbg.Back = bStr[405:448]
-	gbg.Tail = bStr[469:512]
-	return gbg, nil
+	genes, err := ParseHexDecode(hex, opts...)
+	return genes, format, err
 }
 
-// Decode parses the grouped binary and extracts the Axie information into a Gene object.
-func Decode(gbg *GeneBinGroup) (Genes, error) {
-	var genes Genes
-	class, err := getClass(gbg)
+// DetectFormat checks whether the given hex follows the 256 or the 512 bit layout.
+// Leading zeroes are ignored, so any hex with more than 64 significant digits is considered to be in the 512 bit format.
+func DetectFormat(hex string) (GeneFormat, error) {
+	digits, err := cleanHex(hex)
 	if err != nil {
-		return genes, err
+		return 0, err
 	}
-	genes.Class = class
-	region, err := getRegion(gbg)
-	if err != nil {
-		return genes, err
+	digits = strings.TrimLeft(digits, "0")
+	switch {
+	case len(digits) == 0:
+		return 0, fmt.Errorf("%w: empty genes", ErrInvalidHex)
+	case len(digits) <= 64:
+		return Format256, nil
+	case len(digits) <= 128:
+		return Format512, nil
 	}
-	genes.Region = region
-	tag, err := getTag(gbg)
-	if err != nil {
-		return genes, err
+	return 0, fmt.Errorf("%w: exceeds 512 bits", ErrInvalidHex)
+}
+
+// ParseHex divide bits from the 256 hex representation of the string into their respective groups.
+// The hex may be in uppercase, surrounded by whitespace, and may omit the 0x prefix.
+func ParseHex(hex string) (GeneBinGroup, error) {
+	return ParseHexLayout(hex, Layout256)
+}
+
+// cleanHex removes the surrounding whitespace and the 0x prefix of the hex, and checks that only hex digits remain.
+func cleanHex(hex string) (string, error) {
+	digits := strings.TrimSpace(hex)
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		digits = digits[2:]
 	}
-	genes.Tag = tag
-	bodySkin, err := getBodySkin(gbg)
-	if err != nil {
-		return genes, err
+	if digits == "" {
+		return "", fmt.Errorf("%w: empty hex", ErrInvalidHex)
 	}
-	genes.BodySkin = bodySkin
-	pattern, err := getPatternGenes(gbg)
-	if err != nil {
-		return genes, err
+	for i, c := range digits {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return "", fmt.Errorf("%w: unexpected character %q at index %d", ErrInvalidHex, c, i)
+		}
 	}
-	genes.Pattern = pattern
-	color, err := getColorGenes(gbg)
+	return strings.ToLower(digits), nil
+}
+
+// padHex cleans the hex and pads it with leading zeroes to fill the given number of bits.
+func padHex(hex string, size int) (string, error) {
+	digits, err := cleanHex(hex)
 	if err != nil {
-		return genes, err
+		return "", err
 	}
-	genes.Color = color
-	eyes, err := getPart(gbg, gbg.Eyes, Eyes)
-	if err != nil {
-		return genes, err
+	digits = strings.TrimLeft(digits, "0")
+	if len(digits) > size/4 {
+		return "", fmt.Errorf("%w: exceeds %d bits", ErrInvalidHex, size)
 	}
-	genes.Eyes = eyes
-	ears, err := getPart(gbg, gbg.Ears, Ears)
+	return strings.Repeat("0", size/4-len(digits)) + digits, nil
+}
+
+// ParseHex512 divide bits from the 512 hex representation of the string into their respective groups.
+// The hex may be in uppercase, surrounded by whitespace, and may omit the 0x prefix.
+func ParseHex512(hex string) (GeneBinGroup, error) {
+	return ParseHexLayout(hex, Layout512)
+}
+
+// Decode parses the grouped binary and extracts the Axie information into a Gene object.
+func Decode(gbg *GeneBinGroup, opts ...DecodeOption) (Genes, error) {
+	return DecodeLayout(gbg, Layout256, opts...)
+}
+
+// Decode512 parses the grouped binary and extracts the Axie information into a Gene object.
+func Decode512(gbg *GeneBinGroup, opts ...DecodeOption) (Genes, error) {
+	return DecodeLayout(gbg, Layout512, opts...)
+}
+
+// DecodeBits extracts the Axie information from the 256 bit representation of the genes into a Gene object.
+// This yields the same result as Decode without converting each group of bits into a string.
+func DecodeBits(bits *GeneBits256, opts ...DecodeOption) (Genes, error) {
+	return decode(bits, newDecodeOptions(opts))
+}
+
+// DecodeBits512 extracts the Axie information from the 512 bit representation of the genes into a Gene object.
+// This yields the same result as Decode512 without converting each group of bits into a string.
+func DecodeBits512(bits *GeneBits512, opts ...DecodeOption) (Genes, error) {
+	return decode(bits, newDecodeOptions(opts))
+}
+
+// ParseHexDecodeLenient parses a given 256 or 512 hex into a Gene object in lenient mode, as done by
+// WithLenientDecoding. The groups of bits that could not be decoded are returned along with the genes.
+func ParseHexDecodeLenient(hex string, opts ...DecodeOption) (Genes, []*DecodeError, error) {
+	layout, err := detectLayout(hex)
 	if err != nil {
-		return genes, err
+		return Genes{}, nil, err
 	}
-	genes.Ears = ears
-	horn, err := getPart(gbg, gbg.Horn, Horn)
+	gbg, err := ParseHexLayout(hex, layout)
 	if err != nil {
-		return genes, err
+		return Genes{}, nil, err
 	}
-	genes.Horn = horn
-	mouth, err := getPart(gbg, gbg.Mouth, Mouth)
-	if err != nil {
-		return genes, err
+	return DecodeLenient(&gbg, layout, opts...)
+}
+
+// DecodeLenient parses the grouped binary of the given layout into a Gene object in lenient mode, as done by
+// WithLenientDecoding. The groups of bits that could not be decoded are returned along with the genes.
+func DecodeLenient(gbg *GeneBinGroup, layout *Layout, opts ...DecodeOption) (Genes, []*DecodeError, error) {
+	if err := layout.registered(); err != nil {
+		return Genes{}, nil, err
 	}
-	genes.Mouth = mouth
-	back, err := getPart(gbg, gbg.Back, Back)
+	return decodeLenient(layoutGroup{gbg, layout}, newDecodeOptions(opts))
+}
+
+// detectLayout finds the layout of the format of the given hex.
+func detectLayout(hex string) (*Layout, error) {
+	format, err := DetectFormat(hex)
 	if err != nil {
-		return genes, err
+		return nil, err
 	}
-	genes.Back = back
-	tail, err := getPart(gbg, gbg.Tail, Tail)
-	if err != nil {
-		return genes, err
+	if format == Format512 {
+		return Layout512, nil
 	}
-	genes.Tail = tail
-	genes.GeneQuality = getGeneQuality(genes)
-	return genes, nil
+	return Layout256, nil
 }
 
-// Decode512 parses the grouped binary and extracts the Axie information into a Gene object.
-func Decode512(gbg *GeneBinGroup) (Genes, error) {
+// decodeLenient extracts the Axie information in lenient mode, collecting the warnings of this call only.
+func decodeLenient(r binReader, opts decodeOptions) (Genes, []*DecodeError, error) {
+	var warnings []*DecodeError
+	opts.lenient = true
+	opts.warnings = &warnings
+	genes, err := decode(r, opts)
+	return genes, warnings, err
+}
+
+// decode extracts the Axie information into a Gene object, following the layout of the given bits.
+func decode(r binReader, opts decodeOptions) (Genes, error) {
 	var genes Genes
-	class, err := getClass(gbg)
+	if opts.lenient && opts.warnings == nil {
+		return genes, ErrLenientDecoding
+	}
+	c, err := opts.getCatalog()
 	if err != nil {
 		return genes, err
 	}
+	opts.catalog = c
+	class, err := getClass(r)
+	if err = opts.warn(err); err != nil {
+		return genes, err
+	}
 	genes.Class = class
-	region, err := getRegion(gbg)
-	if err != nil {
+	region, err := getRegion(r)
+	if err = opts.warn(err); err != nil {
 		return genes, err
 	}
 	genes.Region = region
-	tag, err := getTag(gbg)
-	if err != nil {
+	tag, err := getTag(r)
+	if err = opts.warn(err); err != nil {
 		return genes, err
 	}
 	genes.Tag = tag
-	bodySkin, err := getBodySkin(gbg)
-	if err != nil {
+	bodySkin, err := getBodySkin(r)
+	if err = opts.warn(err); err != nil {
 		return genes, err
 	}
 	genes.BodySkin = bodySkin
-	pattern, err := getPatternGenes(gbg)
+	pattern, err := getPatternGenes(r)
 	if err != nil {
 		return genes, err
 	}
 	genes.Pattern = pattern
-	color, err := getColorGenes(gbg)
+	body, err := decodeBodyGene(r, opts)
 	if err != nil {
 		return genes, err
 	}
-	genes.Color = color
-	eyes, err := getPart512(gbg, gbg.Eyes, Eyes)
+	genes.Body = body
+	palette, err := getClassPaletteGene(r, c, genes.Class)
+	if err != nil {
+		return genes, err
+	}
+	genes.Color = palette.colorGene()
+	genes.Palette = palette
+	eyes, err := decodePart(r, Eyes, opts)
 	if err != nil {
 		return genes, err
 	}
 	genes.Eyes = eyes
-	ears, err := getPart512(gbg, gbg.Ears, Ears)
+	ears, err := decodePart(r, Ears, opts)
 	if err != nil {
 		return genes, err
 	}
 	genes.Ears = ears
-	horn, err := getPart512(gbg, gbg.Horn, Horn)
+	horn, err := decodePart(r, Horn, opts)
 	if err != nil {
 		return genes, err
 	}
 	genes.Horn = horn
-	mouth, err := getPart512(gbg, gbg.Mouth, Mouth)
+	mouth, err := decodePart(r, Mouth, opts)
 	if err != nil {
 		return genes, err
 	}
 	genes.Mouth = mouth
-	back, err := getPart512(gbg, gbg.Back, Back)
+	back, err := decodePart(r, Back, opts)
 	if err != nil {
 		return genes, err
 	}
 	genes.Back = back
-	tail, err := getPart512(gbg, gbg.Tail, Tail)
+	tail, err := decodePart(r, Tail, opts)
 	if err != nil {
 		return genes, err
 	}
 	genes.Tail = tail
-	genes.GeneQuality = getGeneQuality(genes)
+	genes.GeneQuality = getGeneQuality(genes, opts.scorer)
+	if opts.strict {
+		return genes, c.Validate(genes)
+	}
 	return genes, nil
 }
 
@@ -237,42 +256,52 @@ var binClassMap = map[string]Class{
 	"00100": Aquatic, "00101": Reptile, "10000": Mech, "10001": Dawn, "10010": Dusk,
 }
 
+// binClassIndex is binClassMap keyed by the groups of bits instead of their binary strings.
+var binClassIndex = func() map[Bin]Class {
+	ret := map[Bin]Class{}
+	for bStr, class := range binClassMap {
+		ret[parseBin(bStr)] = class
+	}
+	return ret
+}()
+
 // getClass parses binary values into the class that it represents.
-func getClass(gbg *GeneBinGroup) (Class, error) {
-	if ret, ok := binClassMap[gbg.Class]; ok {
+func getClass(r binReader) (Class, error) {
+	classBin := r.bin("class")
+	if ret, ok := binClassIndex[classBin]; ok {
 		return ret, nil
 	}
-	return "", errors.New(fmt.Sprint("cannot recognize class:", gbg.Class))
+	return "", newDecodeError(ErrUnknownClass, r, "class", classBin)
 }
 
 // binRegionMap contains the details to map binary values into the region that it represents.
 var binRegionMap = map[string]Region{"00000": Global, "00001": Japan}
 
+// binRegionIndex is binRegionMap keyed by the groups of bits instead of their binary strings.
+var binRegionIndex = func() map[Bin]Region {
+	ret := map[Bin]Region{}
+	for bStr, region := range binRegionMap {
+		ret[parseBin(bStr)] = region
+	}
+	return ret
+}()
+
+// japanSkinBin is the part skin that marks an Axie from the 512 bit format as japanese.
+var japanSkinBin = Bin{0b0011, 4}
+
 // getRegion parses binary values into the region that it represents.
-func getRegion(gbg *GeneBinGroup) (Region, error) {
-	if ret, ok := binRegionMap[gbg.Region]; ok {
+func getRegion(r binReader) (Region, error) {
+	regionBin := r.bin("region")
+	if ret, ok := binRegionIndex[regionBin]; ok {
 		return ret, nil
 	}
-	if len(gbg.Region) <= 4 {
-		return Global, errors.New(fmt.Sprint("cannot recognize region:", gbg.Region))
-	}
-	if gbg.Eyes[0:4] == "0011" {
-		return Japan, nil
-	}
-	if gbg.Ears[0:4] == "0011" {
-		return Japan, nil
-	}
-	if gbg.Horn[0:4] == "0011" {
-		return Japan, nil
+	if regionBin.Width <= 4 {
+		return "", newDecodeError(ErrUnknownRegion, r, "region", regionBin)
 	}
-	if gbg.Mouth[0:4] == "0011" {
-		return Japan, nil
-	}
-	if gbg.Back[0:4] == "0011" {
-		return Japan, nil
-	}
-	if gbg.Tail[0:4] == "0011" {
-		return Japan, nil
+	for _, partType := range []PartType{Eyes, Ears, Horn, Mouth, Back, Tail} {
+		if r.bin(string(partType)).Slice(0, 4) == japanSkinBin {
+			return Japan, nil
+		}
 	}
 	return Global, nil
 }
@@ -283,194 +312,197 @@ var binTagMap = map[string]Tag{
 	"000000000000000": NoTag, "000000000000001": Origin, "000000000000010": Meo1, "000000000000011": Meo2,
 }
 
+// binTagIndex is binTagMap keyed by the groups of bits instead of their binary strings.
+var binTagIndex = func() map[Bin]Tag {
+	ret := map[Bin]Tag{}
+	for bStr, tag := range binTagMap {
+		ret[parseBin(bStr)] = tag
+	}
+	return ret
+}()
+
 // getTag parses binary values into the Tag it represents.
-func getTag(gbg *GeneBinGroup) (Tag, error) {
-	if gbg.Tag == "000000000000000" {
-		eyesBionic, _ := getPartSkin(gbg, gbg.Eyes[0:4])
-		earsBionic, _ := getPartSkin(gbg, gbg.Ears[0:4])
-		hornBionic, _ := getPartSkin(gbg, gbg.Horn[0:4])
-		mouthBionic, _ := getPartSkin(gbg, gbg.Mouth[0:4])
-		backBionic, _ := getPartSkin(gbg, gbg.Back[0:4])
-		tailBionic, _ := getPartSkin(gbg, gbg.Tail[0:4])
-		if eyesBionic == Bionic || earsBionic == Bionic || hornBionic == Bionic ||
-			mouthBionic == Bionic || backBionic == Bionic || tailBionic == Bionic {
-			return Agamogenesis, nil
+func getTag(r binReader) (Tag, error) {
+	tagBin := r.bin("tag")
+	if tagBin == (Bin{0, 15}) {
+		for _, partType := range []PartType{Eyes, Ears, Horn, Mouth, Back, Tail} {
+			if skin, _ := getPartSkin(r, r.bin(string(partType)).Slice(0, 4)); skin == Bionic {
+				return Agamogenesis, nil
+			}
 		}
 	}
-	if ret, ok := binTagMap[gbg.Tag]; ok {
+	if ret, ok := binTagIndex[tagBin]; ok {
 		return ret, nil
 	}
-	return NoTag, errors.New(fmt.Sprint("cannot recognize tag:", gbg.Tag))
+	return NoTag, newDecodeError(ErrUnknownTag, r, "tag", tagBin)
 }
 
 // binBodySkinMap contains the details to map binary values into the body skin that it represents.
 var binBodySkinMap = map[string]BodySkin{"0000": DefBodySkin, "0001": Frosty}
 
+// binBodySkinIndex is binBodySkinMap keyed by the groups of bits instead of their binary strings.
+var binBodySkinIndex = func() map[Bin]BodySkin {
+	ret := map[Bin]BodySkin{}
+	for bStr, bodySkin := range binBodySkinMap {
+		ret[parseBin(bStr)] = bodySkin
+	}
+	return ret
+}()
+
 // getBodySkin parses binary values into the BodySkin it represents.
-func getBodySkin(gbg *GeneBinGroup) (BodySkin, error) {
-	if ret, ok := binBodySkinMap[gbg.BodySkin]; ok {
+func getBodySkin(r binReader) (BodySkin, error) {
+	bodySkinBin := r.bin("bodySkin")
+	if ret, ok := binBodySkinIndex[bodySkinBin]; ok {
 		return ret, nil
 	}
-	return DefBodySkin, errors.New(fmt.Sprint("cannot recognize body skin", gbg.Tag))
+	return DefBodySkin, newDecodeError(ErrUnknownBodySkin, r, "bodySkin", bodySkinBin)
 }
 
 // getPatternGenes parses binary values into the patterns that they represent.
-func getPatternGenes(gbg *GeneBinGroup) (PatternGene, error) {
-	bSize := len(gbg.Pattern) / 3
-	return PatternGene{gbg.Pattern[0:bSize], gbg.Pattern[bSize : bSize*2], gbg.Pattern[bSize*2 : bSize*3]}, nil
-}
-
-// classColorMap contains the details to map binary values into the class colors it represents.
-var classColorMap = map[Class]map[string]string{
-	Beast:   {"0010": "ffec51", "0011": "ffa12a", "0100": "f0c66e", "0110": "60afce", "0000": "ffffff"},
-	Bug:     {"0010": "ff7183", "0011": "ff6d61", "0100": "f74e4e", "0000": "ffffff"},
-	Bird:    {"0010": "ff9ab8", "0011": "ffb4bb", "0100": "ff778e", "0000": "ffffff"},
-	Plant:   {"0010": "ccef5e", "0011": "efd636", "0100": "c5ffd9", "0000": "ffffff"},
-	Aquatic: {"0010": "4cffdf", "0011": "2de8f2", "0100": "759edb", "0110": "ff5a71", "0000": "ffffff"},
-	Reptile: {"0010": "fdbcff", "0011": "ef93ff", "0100": "f5e1ff", "0110": "43e27d", "0000": "ffffff"},
-	Mech:    {"0010": "D9D9D9", "0011": "D9D9D9", "0100": "D9D9D9", "0110": "D9D9D9", "0000": "ffffff"},
-	Dusk:    {"0010": "D9D9D9", "0011": "D9D9D9", "0100": "D9D9D9", "0110": "D9D9D9", "0000": "ffffff"},
-	Dawn:    {"0010": "D9D9D9", "0011": "D9D9D9", "0100": "D9D9D9", "0110": "D9D9D9", "0000": "ffffff"},
-}
-
-// getColorGenes parses binary values into the colors that they represent.
-func getColorGenes(gbg *GeneBinGroup) (ColorGene, error) {
-	bSize := len(gbg.Color) / 3
-	class, err := getClass(gbg)
-	if err != nil {
-		return ColorGene{}, err
-	}
-	d := gbg.Color[0:bSize]
-	r1 := gbg.Color[bSize : bSize*2]
-	r2 := gbg.Color[bSize*2 : bSize*3]
-	return ColorGene{
-		classColorMap[class][d[len(d)-4:]],
-		classColorMap[class][r1[len(r1)-4:]],
-		classColorMap[class][r2[len(r2)-4:]],
+func getPatternGenes(r binReader) (PatternGene, error) {
+	pattern := r.bin("pattern")
+	bSize := pattern.Width / 3
+	return PatternGene{
+		pattern.Slice(0, bSize).String(),
+		pattern.Slice(bSize, bSize*2).String(),
+		pattern.Slice(bSize*2, bSize*3).String(),
 	}, nil
 }
 
-// getPart parses binary values into the set of part genes that they represent.
-func getPart(gbg *GeneBinGroup, partBin string, partType PartType) (Part, error) {
-	var part Part
-	dClass := binClassMap[partBin[2:6]]
-	dBin := partBin[6:12]
-	dSkin, err := getPartSkin(gbg, partBin[0:2])
-	if err != nil {
-		return part, err
-	}
-	dName, err := getPartName(dClass, partType, gbg.Region, dBin, dSkin)
-	if err != nil {
-		return part, err
-	}
-	part.D, err = getPartGene(partType, dName)
-	if err != nil {
-		return part, err
+// decodeBodyGene parses the pattern genes into the body shapes and patterns that they represent. Pattern genes that
+// cannot be split are left empty in lenient mode.
+func decodeBodyGene(r binReader, opts decodeOptions) (BodyGene, error) {
+	c, err := opts.getCatalog()
+	if err != nil {
+		return BodyGene{}, err
+	}
+	pattern := r.bin("pattern")
+	bSize := pattern.Width / 3
+	var body [3]BodyPattern
+	for i, slot := range [3]string{"d", "r1", "r2"} {
+		gr := binRange{bSize * i, bSize * (i + 1)}
+		gene := pattern.Slice(gr.start, gr.end)
+		if body[i], err = c.getBodyPattern(gene); err != nil {
+			if err = opts.warn(asSubError(err, r, "pattern", slot, gr, gene)); err != nil {
+				return BodyGene{}, err
+			}
+		}
 	}
+	return BodyGene{body[0], body[1], body[2]}, nil
+}
+
+// getClassPaletteGene parses binary values into the details of the colors that they represent for the given class.
+func getClassPaletteGene(r binReader, c *Catalog, class Class) (PaletteGene, error) {
+	color := r.bin("color")
+	bSize := color.Width / 3
+	return PaletteGene{
+		c.getColor(class, color.Slice(0, bSize)),
+		c.getColor(class, color.Slice(bSize, bSize*2)),
+		c.getColor(class, color.Slice(bSize*2, bSize*3)),
+	}, nil
+}
 
-	r1Class := binClassMap[partBin[12:16]]
-	r1Bin := partBin[16:22]
-	r1Name, err := getPartName(r1Class, partType, gbg.Region, r1Bin, "00")
+// decodePart parses binary values into the set of part genes that they represent. In lenient mode, an unknown skin of
+// the part is left empty, and genes that cannot be decoded are replaced with placeholders.
+func decodePart(r binReader, partType PartType, opts decodeOptions) (Part, error) {
+	var part Part
+	c, err := opts.getCatalog()
 	if err != nil {
 		return part, err
 	}
-	part.R1, err = getPartGene(partType, r1Name)
+	pr := r.layout().parts[partType]
+	partBin := r.bin(string(partType))
+	skinBin := partBin.Slice(pr.skin.start, pr.skin.end)
+	dSkin, err := getPartSkin(r, skinBin)
 	if err != nil {
-		return part, err
+		if err = opts.warn(asPartError(err, r, partType, "skin", pr.skin, skinBin)); err != nil {
+			return part, err
+		}
 	}
-
-	r2Class := binClassMap[partBin[22:26]]
-	r2Bin := partBin[26:32]
-	r2Name, err := getPartName(r2Class, partType, gbg.Region, r2Bin, "00")
-	if err != nil {
-		return part, err
+	regionSkin := GlobalSkin
+	if region, _ := getRegion(r); region == Japan {
+		regionSkin = JapanSkin
 	}
-	part.R2, err = getPartGene(partType, r2Name)
-	if err != nil {
-		return part, err
+	genes := [3]*PartGene{&part.D, &part.R1, &part.R2}
+	for i, gr := range pr.genes {
+		if i == 0 {
+			*genes[i], err = getGene(r, c, partBin, partType, gr, dSkin)
+		} else {
+			*genes[i], err = getRecessiveGene(r, c, partBin, partType, gr, regionSkin)
+		}
+		if err != nil {
+			if err = opts.warn(err); err != nil {
+				return part, err
+			}
+			*genes[i] = getPlaceholderGene(partBin, partType, gr)
+		}
 	}
-
-	part.Mystic = dSkin == Mystic || partBin[0:2] == "0001"
+	part.Mystic = dSkin == Mystic
 	return part, nil
 }
 
-// getPart512 parses binary values into the set of part genes that they represent.
-func getPart512(gbg *GeneBinGroup, partBin string, partType PartType) (Part, error) {
-	var part Part
-	dClass := binClassMap[partBin[4:9]]
-	dBin := partBin[11:17]
-	dSkin, err := getPartSkin(gbg, partBin[0:4])
-	if err != nil {
-		return part, err
-	}
-	dName, err := getPartName(dClass, partType, gbg.Region, dBin, dSkin)
-	if err != nil {
-		return part, err
-	}
-	part.D, err = getPartGene(partType, dName)
-	if err != nil {
-		return part, err
-	}
+// getPlaceholderGene keeps the raw bits of a gene that could not be decoded, along with its class when it is known.
+func getPlaceholderGene(partBin Bin, partType PartType, gr geneRange) PartGene {
+	class := binClassIndex[partBin.Slice(gr.class.start, gr.class.end)]
+	return PartGene{Class: class, Type: partType, Bits: partBin.Slice(gr.class.start, gr.part.end).String()}
+}
 
-	r1Class := binClassMap[partBin[17:22]]
-	r1Bin := partBin[24:30]
-	r1Name, err := getPartName(r1Class, partType, gbg.Region, r1Bin, dSkin)
-	if err != nil {
-		return part, err
-	}
-	part.R1, err = getPartGene(partType, r1Name)
-	if err != nil {
-		return part, err
+// getRecessiveGene parses a recessive gene of a part, using the skin bits of the gene when the layout has them. Like the
+// part skin of the 256 bit format, a gene without special skin bits takes the skin of the region, so the recessive genes
+// of a japan Axie decode into their japan variant when the part has one.
+func getRecessiveGene(r binReader, c *Catalog, partBin Bin, partType PartType, gr geneRange, regionSkin PartSkin) (PartGene, error) {
+	skin := GlobalSkin
+	if gr.skin.end > gr.skin.start {
+		skinBin := partBin.Slice(gr.skin.start, gr.skin.end)
+		var ok bool
+		if skin, ok = binGeneSkinIndex[skinBin]; !ok {
+			return PartGene{}, newPartError(ErrUnknownSkin, r, partType, gr.slot+".skin", gr.skin, skinBin)
+		}
+		if skin == GlobalSkin {
+			skin = regionSkin
+		}
 	}
+	return getGene(r, c, partBin, partType, gr, skin)
+}
 
-	r2Class := binClassMap[partBin[30:35]]
-	r2Bin := partBin[37:43]
-	r2Name, err := getPartName(r2Class, partType, gbg.Region, r2Bin, dSkin)
-	if err != nil {
-		return part, err
+// getGene parses the class and part binary values of a single gene of a part.
+func getGene(r binReader, c *Catalog, partBin Bin, partType PartType, gr geneRange, skin PartSkin) (PartGene, error) {
+	classBin := partBin.Slice(gr.class.start, gr.class.end)
+	class, ok := binClassIndex[classBin]
+	if !ok {
+		return PartGene{}, newPartError(ErrUnknownClass, r, partType, gr.slot+".class", gr.class, classBin)
 	}
-	part.R2, err = getPartGene(partType, r2Name)
+	bin := partBin.Slice(gr.part.start, gr.part.end)
+	partGene, err := c.getPartGene(class, partType, bin, skin)
 	if err != nil {
-		return part, err
+		return PartGene{}, asPartError(err, r, partType, gr.slot, gr.part, bin)
 	}
-
-	part.Mystic = dSkin == Mystic || partBin[0:4] == "0001"
-	return part, nil
+	return partGene, nil
 }
 
-// getPartName parses binary values into the part name that they represent.
-func getPartName(class Class, partType PartType, regionBin string, partBin string, skin PartSkin) (string, error) {
-	traitsJson, err := getTraitsJSON()
-	if err != nil {
-		return "", err
-	}
-	part, ok := traitsJson[class][partType][partBin]
-	if !ok {
-		return "", errors.New(fmt.Sprint("cannot recognize part name:", partType, regionBin, partBin))
+// resolvePartName picks the name of the part variant for the given skin, falling back to the global variant.
+func resolvePartName(part map[string]string, skin PartSkin) string {
+	if partName := part[variantSkin(skin)]; partName != "" {
+		return partName
 	}
-	if partName := part[string(skin)]; partName != "" {
-		return partName, nil
-	}
-	if partName := part[string(Global)]; partName != "" {
-		return partName, nil
+	return part[string(Global)]
+}
+
+// variantSkin converts the skin into the key of its variants in the traits.json file, where both christmas skins share
+// the xmas variants.
+func variantSkin(skin PartSkin) string {
+	if skin == Xmas1 || skin == Xmas2 {
+		return "xmas"
 	}
-	return "", errors.New(fmt.Sprint("cannot recognize part name:", partType, regionBin, partBin))
+	return string(skin)
 }
 
-// getPartGene parses binary values and extract the part information that it represents.
-func getPartGene(partType PartType, partName string) (PartGene, error) {
+// getPartId converts the part name into the id used by the parts.json file.
+func getPartId(partType PartType, partName string) string {
 	partName = strings.ReplaceAll(strings.ToLower(partName), " ", "-")
 	partName = strings.ReplaceAll(partName, ".", "")
 	partName = strings.ReplaceAll(partName, "'", "")
-	partId := fmt.Sprintf("%s-%s", partType, partName)
-	partsJson, err := getPartsJSON()
-	if err != nil {
-		return PartGene{}, err
-	}
-	if partGene, ok := partsJson[partId]; ok {
-		return partGene, nil
-	}
-	return PartGene{}, errors.New(fmt.Sprint("cannot recognize part:", partId))
+	return fmt.Sprintf("%s-%s", partType, partName)
 }
 
 // binPartSkinMap contains the details to map binary values into the part skin that it represents.
@@ -489,45 +521,53 @@ var binPartSkinMap = map[string]PartSkin{
 	"0010":         Bionic,
 }
 
+// binPartSkinIndex is binPartSkinMap keyed by the groups of bits instead of their binary strings.
+var binPartSkinIndex = func() map[Bin]PartSkin {
+	ret := map[Bin]PartSkin{}
+	for bStr, partSkin := range binPartSkinMap {
+		ret[parseBin(bStr)] = partSkin
+	}
+	return ret
+}()
+
+// binGeneSkinMap contains the details to map the skin bits of a single recessive gene into the part skin that it
+// represents. The skin bits of the recessive genes of the 512 bit format are not documented, so this assumes that they
+// use the same values as the 2 bit part skins of the 256 bit format above, which is only checked against the recessive
+// skins of the encode tests. As with the 256 bit format, no special bits stand for the skin of the region, which
+// getRecessiveGene resolves into the japan skin on japan Axies.
+var binGeneSkinMap = map[string]PartSkin{"00": GlobalSkin, "01": Bionic, "10": Xmas2, "11": Mystic}
+
+// binGeneSkinIndex is binGeneSkinMap keyed by the groups of bits instead of their binary strings.
+var binGeneSkinIndex = func() map[Bin]PartSkin {
+	ret := map[Bin]PartSkin{}
+	for bStr, partSkin := range binGeneSkinMap {
+		ret[parseBin(bStr)] = partSkin
+	}
+	return ret
+}()
+
+// xmasBin marks an Axie from the 256 bit format as having xmas parts.
+var xmasBin = Bin{0b010101010101, 12}
+
 // getPartSkin parses binary values and extract the part skin that it represents.
-func getPartSkin(gbg *GeneBinGroup, skinBin string) (PartSkin, error) {
-	partSkin := binPartSkinMap[skinBin]
-	if skinBin == "00" {
-		if gbg.Xmas == "010101010101" {
+func getPartSkin(r binReader, skinBin Bin) (PartSkin, error) {
+	partSkin := binPartSkinIndex[skinBin]
+	if skinBin == (Bin{0, 2}) {
+		if r.bin("xmas") == xmasBin {
 			partSkin = Xmas1
 		} else {
-			partSkin = binPartSkinMap[gbg.Region]
+			partSkin = binPartSkinIndex[r.bin("region")]
 		}
 	}
 	if partSkin == "" {
-		return partSkin, errors.New(fmt.Sprint("cannot recognize part skin:", skinBin, gbg.Xmas))
+		return partSkin, &DecodeError{Err: ErrUnknownSkin, Field: "skin", Bits: skinBin.String()}
 	}
 	return partSkin, nil
 }
 
-// getGeneQuality computes the gene quality of the Axie.
-func getGeneQuality(genes Genes) float64 {
-	geneQuality := 0.0
-	geneQuality += getPartQuality(genes.Class, genes.Eyes)
-	geneQuality += getPartQuality(genes.Class, genes.Ears)
-	geneQuality += getPartQuality(genes.Class, genes.Horn)
-	geneQuality += getPartQuality(genes.Class, genes.Mouth)
-	geneQuality += getPartQuality(genes.Class, genes.Back)
-	geneQuality += getPartQuality(genes.Class, genes.Tail)
-	return math.Round(geneQuality*100) / 100
-}
-
-// getPartQuality computes the quality of Axie's body part.
-func getPartQuality(class Class, part Part) float64 {
-	partQuality := 0.0
-	if part.D.Class == class {
-		partQuality += 76.0 / 6
-	}
-	if part.R1.Class == class {
-		partQuality += 3
-	}
-	if part.R2.Class == class {
-		partQuality += 1
-	}
-	return partQuality
-}
+// Weights given to each gene of a part that matches the class of the Axie when computing the gene quality.
+const (
+	dQuality  = 76.0 / 6
+	r1Quality = 3.0
+	r2Quality = 1.0
+)
@@ -1,8 +1,11 @@
 package agp
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -14,36 +17,38 @@ func TestDecode(t *testing.T) {
 		Tag:      NoTag,
 		BodySkin: DefBodySkin,
 		Pattern:  PatternGene{"000001", "000111", "000110"},
+		Body:     BodyGene{BodyPattern{NormalBody, 1}, BodyPattern{NormalBody, 7}, BodyPattern{NormalBody, 6}},
 		Color:    ColorGene{"f0c66e", "ffec51", "f0c66e"},
+		Palette:  PaletteGene{Color{"0100", "f0c66e", "Sand", "beast"}, Color{"0010", "ffec51", "Sunflower", "beast"}, Color{"0100", "f0c66e", "Sand", "beast"}},
 		Eyes: Part{
-			D:  PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby"},
-			R1: PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby"},
-			R2: PartGene{"eyes-blossom", Plant, "", Eyes, "Blossom"},
+			D:  PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby", GlobalSkin, ""},
+			R1: PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby", GlobalSkin, ""},
+			R2: PartGene{"eyes-blossom", Plant, "", Eyes, "Blossom", GlobalSkin, ""},
 		},
 		Ears: Part{
-			D:  PartGene{"ears-lotus", Plant, "", Ears, "Lotus"},
-			R1: PartGene{"ears-nut-cracker", Beast, "", Ears, "Nut Cracker"},
-			R2: PartGene{"ears-inkling", Aquatic, "", Ears, "Inkling"},
+			D:  PartGene{"ears-lotus", Plant, "", Ears, "Lotus", GlobalSkin, ""},
+			R1: PartGene{"ears-nut-cracker", Beast, "", Ears, "Nut Cracker", GlobalSkin, ""},
+			R2: PartGene{"ears-inkling", Aquatic, "", Ears, "Inkling", GlobalSkin, ""},
 		},
 		Horn: Part{
-			D:  PartGene{"horn-rose-bud", Plant, "", Horn, "Rose Bud"},
-			R1: PartGene{"horn-caterpillars", Bug, "", Horn, "Caterpillars"},
-			R2: PartGene{"horn-dual-blade", Beast, "", Horn, "Dual Blade"},
+			D:  PartGene{"horn-rose-bud", Plant, "", Horn, "Rose Bud", GlobalSkin, ""},
+			R1: PartGene{"horn-caterpillars", Bug, "", Horn, "Caterpillars", GlobalSkin, ""},
+			R2: PartGene{"horn-dual-blade", Beast, "", Horn, "Dual Blade", GlobalSkin, ""},
 		},
 		Mouth: Part{
-			D:  PartGene{"mouth-tiny-turtle", Reptile, "", Mouth, "Tiny Turtle"},
-			R1: PartGene{"mouth-piranha", Aquatic, "", Mouth, "Piranha"},
-			R2: PartGene{"mouth-serious", Plant, "", Mouth, "Serious"},
+			D:  PartGene{"mouth-tiny-turtle", Reptile, "", Mouth, "Tiny Turtle", GlobalSkin, ""},
+			R1: PartGene{"mouth-piranha", Aquatic, "", Mouth, "Piranha", GlobalSkin, ""},
+			R2: PartGene{"mouth-serious", Plant, "", Mouth, "Serious", GlobalSkin, ""},
 		},
 		Back: Part{
-			D:  PartGene{"back-balloon", Bird, "", Back, "Balloon"},
-			R1: PartGene{"back-jaguar", Beast, "", Back, "Jaguar"},
-			R2: PartGene{"back-jaguar", Beast, "", Back, "Jaguar"},
+			D:  PartGene{"back-balloon", Bird, "", Back, "Balloon", GlobalSkin, ""},
+			R1: PartGene{"back-jaguar", Beast, "", Back, "Jaguar", GlobalSkin, ""},
+			R2: PartGene{"back-jaguar", Beast, "", Back, "Jaguar", GlobalSkin, ""},
 		},
 		Tail: Part{
-			D:  PartGene{"tail-ant", Bug, "", Tail, "Ant"},
-			R1: PartGene{"tail-hot-butt", Plant, "", Tail, "Hot Butt"},
-			R2: PartGene{"tail-swallow", Bird, "", Tail, "Swallow"},
+			D:  PartGene{"tail-ant", Bug, "", Tail, "Ant", GlobalSkin, ""},
+			R1: PartGene{"tail-hot-butt", Plant, "", Tail, "Hot Butt", GlobalSkin, ""},
+			R2: PartGene{"tail-swallow", Bird, "", Tail, "Swallow", GlobalSkin, ""},
 		},
 		GeneQuality: 23.67,
 	}
@@ -122,27 +127,36 @@ func TestGetClass(t *testing.T) {
 	}
 }
 
-func TestGetColorGenes(t *testing.T) {
+func TestGetClassPaletteGene(t *testing.T) {
+	c, _ := DefaultCatalog()
+	type args struct {
+		class Class
+		gbg   *GeneBinGroup
+	}
 	tests := []struct {
 		name string
-		bin  *GeneBinGroup
-		want ColorGene
+		args args
+		want PaletteGene
 	}{
-		{"VALID_COLOR", &GeneBinGroup{Class: "0000", Color: "001000110010"}, ColorGene{"ffec51", "ffa12a", "ffec51"}},
-		{"INVALID_CLASS", &GeneBinGroup{Class: "0010", Color: "001100110011"}, ColorGene{"ffb4bb", "ffb4bb", "ffb4bb"}},
-		{"INVALID_COLORS", &GeneBinGroup{Class: "0100", Color: "001000110010"}, ColorGene{"4cffdf", "2de8f2", "4cffdf"}},
+		{"VALID_COLOR", args{Beast, &GeneBinGroup{Color: "001000110000"}}, PaletteGene{Color{"0010", "ffec51", "Sunflower", "beast"}, Color{"0011", "ffa12a", "Tangerine", "beast"}, Color{"0000", "ffffff", "White", "shared"}}},
+		{"VALID_COLOR_512", args{Reptile, &GeneBinGroup{Class: "00101", Color: "000010000011000110"}}, PaletteGene{Color{"000010", "fdbcff", "Pink Lavender", "reptile"}, Color{"000011", "ef93ff", "Orchid", "reptile"}, Color{"000110", "43e27d", "Emerald", "reptile"}}},
+		{"UNKNOWN_COLOR", args{Bug, &GeneBinGroup{Color: "011000101111"}}, PaletteGene{Color{"0110", UnknownColor, UnknownColor, ""}, Color{"0010", "ff7183", "Coral", "bug"}, Color{"1111", UnknownColor, UnknownColor, ""}}},
+		{"UNKNOWN_COLOR_512", args{Dusk, &GeneBinGroup{Class: "10010", Color: "100010000010000000"}}, PaletteGene{Color{"100010", UnknownColor, UnknownColor, ""}, Color{"000010", UnknownColor, UnknownColor, ""}, Color{"000000", "ffffff", "White", "shared"}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, _ := getColorGenes(tt.bin)
+			got, err := getClassPaletteGene(tt.args.gbg, c, tt.args.class)
+			if err != nil {
+				t.Fatalf("getClassPaletteGene() unexpected error = %v", err)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Fatalf("getColorGenes() got = %v, want %v", got, tt.want)
+				t.Fatalf("getClassPaletteGene() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestGetPart(t *testing.T) {
+func TestDecodePart(t *testing.T) {
 	type args struct {
 		partType PartType
 		gbg      *GeneBinGroup
@@ -155,7 +169,12 @@ func TestGetPart(t *testing.T) {
 	}{
 		{
 			"VALID_PART",
-			args{Eyes, &GeneBinGroup{Region: "00000", Eyes: "00000000101000000010100011001010"}}, Part{PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby"}, PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby"}, PartGene{"eyes-blossom", Plant, "", Eyes, "Blossom"}, false},
+			args{Eyes, &GeneBinGroup{Region: "00000", Eyes: "00000000101000000010100011001010"}}, Part{PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby", GlobalSkin, ""}, PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby", GlobalSkin, ""}, PartGene{"eyes-blossom", Plant, "", Eyes, "Blossom", GlobalSkin, ""}, false},
+			false,
+		},
+		{
+			"RECESSIVE_SKINS_512",
+			args{Eyes, &GeneBinGroup{Class: "00000", Eyes: "0000000000000101000000110000100001101001010"}}, Part{PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby", GlobalSkin, ""}, PartGene{"eyes-calico-zeal", Beast, "mystic", Eyes, "Calico Zeal", Mystic, ""}, PartGene{"eyes-blossom", Plant, "", Eyes, "Blossom", Bionic, ""}, false},
 			false,
 		},
 		{
@@ -167,93 +186,33 @@ func TestGetPart(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getPart(tt.args.gbg, tt.args.gbg.Eyes, Eyes)
+			got, err := decodePart(tt.args.gbg, Eyes, decodeOptions{})
 			if err == nil && tt.wantErr {
-				t.Fatalf("getPart() expected an error")
+				t.Fatalf("decodePart() expected an error")
 				return
 			}
 			if err != nil {
 				if !tt.wantErr {
-					t.Fatalf("getPart() unexpected error = %v", err)
+					t.Fatalf("decodePart() unexpected error = %v", err)
 				}
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Fatalf("getPart() got = %v, want %v", got, tt.want)
+				t.Fatalf("decodePart() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestGetPartGene(t *testing.T) {
-	type args struct {
-		partType PartType
-		partName string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    PartGene
-		wantErr bool
-	}{
-		{"VALID_PART_GENE", args{Ears, "Nut Cracker"}, PartGene{"ears-nut-cracker", Beast, "", Ears, "Nut Cracker"}, false},
-		{"INVALID_COMBINATION", args{Ears, "Chubby"}, PartGene{}, true},
-		{"INVALID_PART_NAME", args{Ears, "Ballon Mouth"}, PartGene{}, true},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := getPartGene(tt.args.partType, tt.args.partName)
-			if err == nil && tt.wantErr {
-				t.Fatalf("getPartGene() expected an error")
-				return
-			}
-			if err != nil {
-				if !tt.wantErr {
-					t.Fatalf("getPartGene() unexpected error = %v", err)
-				}
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Fatalf("getPartGene() got = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestGetPartName(t *testing.T) {
-	type args struct {
-		class     Class
-		partType  PartType
-		regionBin string
-		partBin   string
-		partSkin  PartSkin
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
-	}{
-		{"VALID_PART_NAME", args{Beast, Ears, "00000", "001000", GlobalSkin}, "Zen", false},
-		{"INVALID_PART_BIN", args{Beast, Ears, "00000", "100100", GlobalSkin}, "", true},
+func TestDecodeJapanRecessives(t *testing.T) {
+	// The first recessive gene of the back has no special skin bits, so it takes the japan variant of the region.
+	genes, err := ParseHexDecode512("0x180000000000000001008040020c00000000008c106083040000000c086043020000000c2861830a0000000c1860c30a0000018c3060830c0000010c08604302")
+	if err != nil {
+		t.Fatalf("ParseHexDecode512() unexpected error = %v", err)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := getPartName(tt.args.class, tt.args.partType, tt.args.regionBin, tt.args.partBin, tt.args.partSkin)
-			if err == nil && tt.wantErr {
-				t.Fatalf("getPartName() expected an error")
-				return
-			}
-			if err != nil {
-				if !tt.wantErr {
-					t.Fatalf("getPartName() unexpected error = %v", err)
-				}
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Fatalf("getPartName() got = %v, want %v", got, tt.want)
-			}
-		})
+	want := PartGene{"back-yakitori", Plant, "japan", Back, "Yakitori", JapanSkin, ""}
+	if genes.Region != Japan || genes.Back.R1 != want {
+		t.Fatalf("ParseHexDecode512() got = %v %v, want %v %v", genes.Region, genes.Back.R1, Japan, want)
 	}
 }
 
@@ -271,11 +230,12 @@ func TestGetPartSkin(t *testing.T) {
 		{"GLOBAL_SKIN", args{"00000", "00"}, GlobalSkin, false},
 		{"XMAS_SKIN", args{"00000", "10"}, Xmas2, false},
 		{"MYSTIC_SKIN", args{"00000", "11"}, Mystic, false},
-		{"INVALID_SKIN", args{"00000", "01"}, "", true},
+		{"BIONIC_SKIN", args{"00000", "01"}, Bionic, false},
+		{"INVALID_SKIN", args{"00000", "111"}, "", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getPartSkin(&GeneBinGroup{Region: tt.args.regionBin}, tt.args.skinBin)
+			got, err := getPartSkin(&GeneBinGroup{Region: tt.args.regionBin}, parseBin(tt.args.skinBin))
 			if err == nil && tt.wantErr {
 				t.Fatalf("getPartSkin() expected an error")
 				return
@@ -312,6 +272,32 @@ func TestGetPatternGenes(t *testing.T) {
 	}
 }
 
+func TestDecodeBodyGene(t *testing.T) {
+	tests := []struct {
+		name    string
+		bin     *GeneBinGroup
+		want    BodyGene
+		wantErr bool
+	}{
+		{"VALID_PATTERN", &GeneBinGroup{Pattern: "000001000111000110"}, BodyGene{BodyPattern{NormalBody, 1}, BodyPattern{NormalBody, 7}, BodyPattern{NormalBody, 6}}, false},
+		{"VALID_PATTERN_512", &GeneBinGroup{Class: "00000", Pattern: "001100000011000001110000010"}, BodyGene{BodyPattern{CurlyBody, 32}, BodyPattern{BigYakBody, 1}, BodyPattern{SpikyBody, 2}}, false},
+		{"ALL_SHAPES", &GeneBinGroup{Pattern: "010000100000101000"}, BodyGene{BodyPattern{SumoBody, 0}, BodyPattern{WetDogBody, 0}, BodyPattern{FuzzyBody, 0}}, false},
+		{"UNKNOWN_SHAPE", &GeneBinGroup{Pattern: "000001111111000110"}, BodyGene{BodyPattern{NormalBody, 1}, BodyPattern{UnknownShape, 7}, BodyPattern{NormalBody, 6}}, false},
+		{"INVALID_BINARY", &GeneBinGroup{Region: "01010101010"}, BodyGene{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeBodyGene(tt.bin, decodeOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeBodyGene() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("decodeBodyGene() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetRegion(t *testing.T) {
 	tests := []struct {
 		name string
@@ -320,6 +306,7 @@ func TestGetRegion(t *testing.T) {
 	}{
 		{"VALID_REGION", &GeneBinGroup{Region: "00000", Eyes: "0000", Ears: "0000", Horn: "0000", Mouth: "0000", Back: "0000", Tail: "0000"}, Global},
 		{"INVALID_REGION", &GeneBinGroup{Region: "000000000000000000", Eyes: "0000", Ears: "0011", Horn: "0000", Mouth: "0000", Back: "0000", Tail: "0000"}, Japan},
+		{"UNKNOWN_REGION", &GeneBinGroup{Region: "0010"}, ""},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -381,12 +368,36 @@ func TestParseHex(t *testing.T) {
 			"",
 			true,
 		},
+		{
+			"UPPERCASE_HEX",
+			"0X11C642400A028CA14A428C20CC011080C61180A0820180604233082",
+			"0000000000000000000000000000000000000001000111000110010000100100000000001010000000101000110010100001010010100100001010001100001000001100110000000001000100001000000011000110000100011000000010100000100000100000000110000000011000000100001000110011000010000010",
+			false,
+		},
+		{
+			"UNPREFIXED_HEX",
+			"  11c642400a028ca14a428c20cc011080c61180a0820180604233082\n",
+			"0000000000000000000000000000000000000001000111000110010000100100000000001010000000101000110010100001010010100100001010001100001000001100110000000001000100001000000011000110000100011000000010100000100000100000000110000000011000000100001000110011000010000010",
+			false,
+		},
 		{
 			"EMPTY_HEX",
 			"",
 			"",
 			true,
 		},
+		{
+			"PREFIX_ONLY_HEX",
+			"0x",
+			"",
+			true,
+		},
+		{
+			"OVERSIZED_HEX",
+			"0x1" + strings.Repeat("0", 64),
+			"",
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -415,6 +426,32 @@ func TestParseHex(t *testing.T) {
 	}
 }
 
+func TestParseHex512(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		wantErr bool
+	}{
+		{"VALID_HEX", "0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302", false},
+		{"SHORT_HEX", "0x1", false},
+		{"EMPTY_HEX", "", true},
+		{"INVALID_HEX", "0x18000000000000000100804002hg", true},
+		{"OVERSIZED_HEX", "0x1" + strings.Repeat("0", 128), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseHex512(tt.hex)
+			if err == nil && tt.wantErr {
+				t.Fatalf("ParseHex512() expected an error")
+				return
+			}
+			if err != nil && !tt.wantErr {
+				t.Fatalf("ParseHex512() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
 func TestGetGeneQuality(t *testing.T) {
 	tests := []struct {
 		name string
@@ -428,9 +465,101 @@ func TestGetGeneQuality(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			genes, _ := ParseHexDecode(tt.hex)
-			if got := getGeneQuality(genes); got != tt.want {
+			if got := getGeneQuality(genes, ClassQuality); got != tt.want {
 				t.Fatalf("getGeneQuality() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestParseHexDecodeAuto(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		want    GeneFormat
+		wantErr bool
+	}{
+		{"HEX_256", "0x11c642400a028ca14a428c20cc011080c61180a0820180604233082", Format256, false},
+		{"HEX_256_PADDED", "0x00000000011c642400a028ca14a428c20cc011080c61180a0820180604233082", Format256, false},
+		{"HEX_512", "0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302", Format512, false},
+		{"HEX_512_TRIMMED", "0x40e06102100000000000002801430a00000014288143020000000c300084080000000c1820c00a000000080800c0060000000408618202", Format512, false},
+		{"MISSING_PREFIX", "11c642400a028ca14a428c20cc011080c61180a0820180604233082", Format256, false},
+		{"INVALID_CHARACTER", "0x11c642400a028ca14a428c20cc011080c61180a0820180604233g82", 0, true},
+		{"EMPTY_HEX", "0x", 0, true},
+		{"OVERSIZED_HEX", "0x1" + strings.Repeat("0", 128), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genes, got, err := ParseHexDecodeAuto(tt.hex)
+			if err == nil && tt.wantErr {
+				t.Fatalf("ParseHexDecodeAuto() expected an error")
+				return
+			}
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("ParseHexDecodeAuto() unexpected error = %v", err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("ParseHexDecodeAuto() got = %v, want %v", got, tt.want)
+			}
+			if genes.Class == "" {
+				t.Fatalf("ParseHexDecodeAuto() returned empty genes")
+			}
+		})
+	}
+}
+
+func TestLenientDecoding(t *testing.T) {
+	gbg, _ := ParseHex("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	gbg.Class = "1111"
+	gbg.Pattern = gbg.Pattern[:6] + "111000" + gbg.Pattern[12:]
+	gbg.Eyes = gbg.Eyes[:6] + "111111" + gbg.Eyes[12:]
+	want, _ := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	if _, err := Decode(&gbg); err == nil {
+		t.Fatalf("Decode() expected an error")
+	}
+	got, warnings, err := DecodeLenient(&gbg, Layout256)
+	if err != nil {
+		t.Fatalf("DecodeLenient() unexpected error = %v", err)
+	}
+	var fields []string
+	for _, warning := range warnings {
+		fields = append(fields, warning.Field)
+	}
+	if wantFields := []string{"class", "eyes.d"}; !reflect.DeepEqual(fields, wantFields) {
+		t.Fatalf("DecodeLenient() warnings got = %v, want %v", warnings, wantFields)
+	}
+	if placeholder := (PartGene{Class: Beast, Type: Eyes, Bits: "0000111111"}); got.Eyes.D != placeholder {
+		t.Fatalf("DecodeLenient() eyes got = %v, want %v", got.Eyes.D, placeholder)
+	}
+	if got.Class != "" || got.Body.R1.Shape != UnknownShape || got.Body.D != want.Body.D {
+		t.Fatalf("DecodeLenient() got = %v, want the unknown fields marked", got)
+	}
+	if got.Eyes.R1 != want.Eyes.R1 || got.Tail != want.Tail {
+		t.Fatalf("DecodeLenient() got = %v, want the known parts of %v", got, want)
+	}
+	// Decode functions that only return the genes would drop the warnings.
+	if _, err := Decode(&gbg, WithLenientDecoding()); !errors.Is(err, ErrLenientDecoding) {
+		t.Fatalf("Decode() error = %v, want %v", err, ErrLenientDecoding)
+	}
+	if _, err := NewDecoder(nil, WithLenientDecoding()).Decode(&gbg); !errors.Is(err, ErrLenientDecoding) {
+		t.Fatalf("Decoder.Decode() error = %v, want %v", err, ErrLenientDecoding)
+	}
+	hex, _ := FormatHex(&gbg)
+	for name, decode := range map[string]func(string) (Genes, []*DecodeError, error){
+		"ParseHexDecodeLenient()":         func(hex string) (Genes, []*DecodeError, error) { return ParseHexDecodeLenient(hex) },
+		"Decoder.ParseHexDecodeLenient()": NewDecoder(nil, WithLenientDecoding()).ParseHexDecodeLenient,
+	} {
+		if genes, w, err := decode(hex); err != nil || !reflect.DeepEqual(genes, got) || !reflect.DeepEqual(w, warnings) {
+			t.Fatalf("%s got = %v, %v, %v, want %v, %v", name, genes, w, err, got, warnings)
+		}
+	}
+	results, _ := DecodeBatch(context.Background(), []string{hex, hex}, BatchOptions{Workers: 2, Options: []DecodeOption{WithLenientDecoding()}})
+	for _, result := range results {
+		if result.Err != nil || !reflect.DeepEqual(result.Warnings, warnings) {
+			t.Fatalf("DecodeBatch() warnings got = %v, %v, want %v", result.Warnings, result.Err, warnings)
+		}
+	}
+}
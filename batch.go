@@ -0,0 +1,174 @@
+package agp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// BatchOptions configures how DecodeBatch and DecodeStream decode the genes.
+type BatchOptions struct {
+	// Workers is the number of genes decoded at the same time. Defaults to the number of CPUs when zero.
+	Workers int
+	// Format forces every hex to be decoded in the given format. The format is detected for each hex when zero.
+	Format GeneFormat
+	// Options are applied when decoding each hex, such as WithCatalog or WithStrictValidation.
+	Options []DecodeOption
+}
+
+// workers returns the number of workers to be started.
+func (opts BatchOptions) workers() int {
+	if opts.Workers > 0 {
+		return opts.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// BatchResult holds the outcome of decoding a single hex of a batch.
+type BatchResult struct {
+	Index  int        `json:"index"`
+	Hex    string     `json:"hex,omitempty"`
+	Format GeneFormat `json:"format,omitempty"`
+	Genes  Genes      `json:"genes,omitempty"`
+	Err    error      `json:"-"`
+	// Warnings lists the groups of bits that could not be decoded when WithLenientDecoding is among the options.
+	Warnings []*DecodeError `json:"-"`
+}
+
+// DecodeBatch decodes each hex using a pool of workers. The results are in the same order as the given hex, and each
+// of them holds its own error. When the context is cancelled, the remaining hex are left with the context's error,
+// which is also returned.
+func DecodeBatch(ctx context.Context, hexes []string, opts BatchOptions) ([]BatchResult, error) {
+	results := make([]BatchResult, len(hexes))
+	decodeOpts := newDecodeOptions(opts.Options)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.workers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = decodeBatchHex(i, hexes[i], opts.Format, decodeOpts)
+			}
+		}()
+	}
+	next := 0
+dispatch:
+	for ; next < len(hexes) && ctx.Err() == nil; next++ {
+		select {
+		case indexes <- next:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	for i := next; i < len(hexes); i++ {
+		results[i] = BatchResult{Index: i, Hex: hexes[i], Err: ctx.Err()}
+	}
+	if next < len(hexes) {
+		return results, ctx.Err()
+	}
+	return results, nil
+}
+
+// DecodeStream decodes each hex received from the channel using a pool of workers. The results are sent in the same
+// order as the hex were received, and each of them holds its own error. The returned channel is closed once the input
+// channel is closed and all of its hex are decoded, or once the context is cancelled.
+func DecodeStream(ctx context.Context, hexes <-chan string, opts BatchOptions) <-chan BatchResult {
+	type job struct {
+		index  int
+		hex    string
+		result chan BatchResult
+	}
+	workers := opts.workers()
+	decodeOpts := newDecodeOptions(opts.Options)
+	jobs := make(chan job)
+	// Pending results are queued in the order they were received, which bounds the number of hex in flight.
+	pending := make(chan chan BatchResult, workers)
+	out := make(chan BatchResult)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				j.result <- decodeBatchHex(j.index, j.hex, opts.Format, decodeOpts)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for index := 0; ; index++ {
+			var hex string
+			var ok bool
+			select {
+			case hex, ok = <-hexes:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			j := job{index, hex, make(chan BatchResult, 1)}
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case pending <- j.result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		defer close(out)
+		for result := range pending {
+			r := <-result
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// decodeBatchHex decodes a single hex of a batch in the given format, detecting the format when it is zero.
+func decodeBatchHex(index int, hex string, format GeneFormat, opts decodeOptions) BatchResult {
+	result := BatchResult{Index: index, Hex: hex, Format: format}
+	if format == 0 {
+		if result.Format, result.Err = DetectFormat(hex); result.Err != nil {
+			return result
+		}
+	}
+	var r binReader
+	switch result.Format {
+	case Format256:
+		bits, err := ParseBits(hex)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		r = &bits
+	case Format512:
+		bits, err := ParseBits512(hex)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		r = &bits
+	default:
+		result.Err = errors.New(fmt.Sprint("unknown format:", result.Format))
+		return result
+	}
+	if opts.lenient {
+		result.Genes, result.Warnings, result.Err = decodeLenient(r, opts)
+	} else {
+		result.Genes, result.Err = decode(r, opts)
+	}
+	return result
+}
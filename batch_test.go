@@ -0,0 +1,86 @@
+package agp
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var batchHexes = []string{
+	"0x11c642400a028ca14a428c20cc011080c61180a0820180604233082",
+	"0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302",
+	"0xhg",
+	"0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2",
+	"0x10000000080c144410a0294208a220881040080a0c24180410c3194200200904",
+}
+
+func TestDecodeBatch(t *testing.T) {
+	got, err := DecodeBatch(context.Background(), batchHexes, BatchOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("DecodeBatch() unexpected error = %v", err)
+	}
+	checkBatchResults(t, "DecodeBatch()", got)
+}
+
+func TestDecodeBatchOptions(t *testing.T) {
+	got, err := DecodeBatch(context.Background(), batchHexes, BatchOptions{Workers: 2, Options: []DecodeOption{WithQualityScorer(PureCount)}})
+	if err != nil {
+		t.Fatalf("DecodeBatch() unexpected error = %v", err)
+	}
+	for i, hex := range batchHexes {
+		want, _, err := ParseHexDecodeAuto(hex, WithQualityScorer(PureCount))
+		if (err != nil) != (got[i].Err != nil) {
+			t.Fatalf("DecodeBatch() error = %v, want %v", got[i].Err, err)
+		}
+		if !reflect.DeepEqual(got[i].Genes, want) {
+			t.Fatalf("DecodeBatch() got = %v, want %v", got[i].Genes, want)
+		}
+	}
+}
+
+func TestDecodeBatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err := DecodeBatch(ctx, batchHexes, BatchOptions{Workers: 2})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("DecodeBatch() got = %v, want %v", err, context.Canceled)
+	}
+	if len(got) != len(batchHexes) || !errors.Is(got[len(got)-1].Err, context.Canceled) {
+		t.Fatalf("DecodeBatch() expected the remaining results to hold the context's error")
+	}
+}
+
+func TestDecodeStream(t *testing.T) {
+	hexes := make(chan string)
+	go func() {
+		defer close(hexes)
+		for _, hex := range batchHexes {
+			hexes <- hex
+		}
+	}()
+	var got []BatchResult
+	for result := range DecodeStream(context.Background(), hexes, BatchOptions{Workers: 3}) {
+		got = append(got, result)
+	}
+	checkBatchResults(t, "DecodeStream()", got)
+}
+
+// checkBatchResults compares the results of a batch against decoding each of batchHexes one by one.
+func checkBatchResults(t *testing.T, name string, got []BatchResult) {
+	if len(got) != len(batchHexes) {
+		t.Fatalf("%s got %d results, want %d", name, len(got), len(batchHexes))
+	}
+	for i, result := range got {
+		want, format, err := ParseHexDecodeAuto(batchHexes[i])
+		if result.Index != i || result.Hex != batchHexes[i] {
+			t.Fatalf("%s result %d is out of order", name, i)
+		}
+		if (err != nil) != (result.Err != nil) {
+			t.Fatalf("%s result %d error got = %v, want %v", name, i, result.Err, err)
+		}
+		if err == nil && (result.Format != format || !reflect.DeepEqual(result.Genes, want)) {
+			t.Fatalf("%s result %d got = %v, want %v", name, i, result.Genes, want)
+		}
+	}
+}
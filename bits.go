@@ -0,0 +1,199 @@
+package agp
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Bin holds the value of a group of bits along with the number of bits in the group. Leading zeroes are significant,
+// so Bin{1, 4} ("0001") and Bin{1, 5} ("00001") are different groups of bits.
+type Bin struct {
+	Value uint64
+	Width int
+}
+
+// binStrings caches the binary representation of the small groups of bits, such as the pattern genes.
+var binStrings = func() [10][]string {
+	var ret [10][]string
+	for width := 1; width < len(ret); width++ {
+		ret[width] = make([]string, 1<<width)
+		for value := range ret[width] {
+			ret[width][value] = fmt.Sprintf("%0*b", width, value)
+		}
+	}
+	return ret
+}()
+
+// String formats the group of bits as a binary string of its width, same as the fields of GeneBinGroup.
+// Bits of the value beyond the width are ignored.
+func (b Bin) String() string {
+	if b.Width <= 0 {
+		return ""
+	}
+	value := b.Value
+	if b.Width < 64 {
+		value &= 1<<uint(b.Width) - 1
+	}
+	if b.Width < len(binStrings) {
+		return binStrings[b.Width][value]
+	}
+	return fmt.Sprintf("%0*b", b.Width, value)
+}
+
+// Slice extracts the bits from start to end, where the first bit is the most significant one.
+// Bits outside of the group are read as zeroes.
+func (b Bin) Slice(start, end int) Bin {
+	if start < 0 || end > b.Width || start >= end {
+		if start < end {
+			return Bin{Width: end - start}
+		}
+		return Bin{}
+	}
+	width := end - start
+	return Bin{Value: b.Value >> uint(b.Width-end) & (1<<uint(width) - 1), Width: width}
+}
+
+// parseBin converts a binary string into a Bin. Strings that are not binary or exceed 64 bits are read as empty.
+func parseBin(bStr string) Bin {
+	value, err := strconv.ParseUint(bStr, 2, 64)
+	if err != nil {
+		return Bin{}
+	}
+	return Bin{Value: value, Width: len(bStr)}
+}
+
+// GeneBits256 holds the 256 bit representation of the genes as 64 bit words, starting from the most significant one.
+// Unlike GeneBinGroup, it can be decoded without converting each group of bits into a string.
+type GeneBits256 [4]uint64
+
+// GeneBits512 holds the 512 bit representation of the genes as 64 bit words, starting from the most significant one.
+// Unlike GeneBinGroup, it can be decoded without converting each group of bits into a string.
+type GeneBits512 [8]uint64
+
+// ParseBits converts the 256 hex representation of the genes into GeneBits256.
+func ParseBits(hex string) (GeneBits256, error) {
+	var bits GeneBits256
+	return bits, parseWords(hex, bits[:])
+}
+
+// ParseBits512 converts the 512 hex representation of the genes into GeneBits512.
+func ParseBits512(hex string) (GeneBits512, error) {
+	var bits GeneBits512
+	return bits, parseWords(hex, bits[:])
+}
+
+// parseWords fills the words with the value of the hex, starting from the most significant word.
+func parseWords(hex string, words []uint64) error {
+	digits, err := padHex(hex, len(words)*64)
+	if err != nil {
+		return err
+	}
+	for i := range words {
+		if words[i], err = strconv.ParseUint(digits[i*16:(i+1)*16], 16, 64); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Bits extracts the bits from start to end, where the first bit is the most significant one.
+func (b *GeneBits256) Bits(start, end int) Bin {
+	return getWordBits(b[:], start, end)
+}
+
+// Bits extracts the bits from start to end, where the first bit is the most significant one.
+func (b *GeneBits512) Bits(start, end int) Bin {
+	return getWordBits(b[:], start, end)
+}
+
+// Class returns the bits of the class of the genes.
+func (b *GeneBits256) Class() Bin { return b.bin("class") }
+
+// Region returns the bits of the region of the genes.
+func (b *GeneBits256) Region() Bin { return b.bin("region") }
+
+// Tag returns the bits of the tag of the genes.
+func (b *GeneBits256) Tag() Bin { return b.bin("tag") }
+
+// BodySkin returns the bits of the body skin of the genes.
+func (b *GeneBits256) BodySkin() Bin { return b.bin("bodySkin") }
+
+// Xmas returns the bits of the xmas skin of the genes.
+func (b *GeneBits256) Xmas() Bin { return b.bin("xmas") }
+
+// Pattern returns the bits of the pattern genes.
+func (b *GeneBits256) Pattern() Bin { return b.bin("pattern") }
+
+// Color returns the bits of the color genes.
+func (b *GeneBits256) Color() Bin { return b.bin("color") }
+
+// Part returns the bits of the given part.
+func (b *GeneBits256) Part(partType PartType) Bin { return b.bin(string(partType)) }
+
+// Class returns the bits of the class of the genes.
+func (b *GeneBits512) Class() Bin { return b.bin("class") }
+
+// Region returns the bits of the region of the genes.
+func (b *GeneBits512) Region() Bin { return b.bin("region") }
+
+// Tag returns the bits of the tag of the genes.
+func (b *GeneBits512) Tag() Bin { return b.bin("tag") }
+
+// BodySkin returns the bits of the body skin of the genes.
+func (b *GeneBits512) BodySkin() Bin { return b.bin("bodySkin") }
+
+// Pattern returns the bits of the pattern genes.
+func (b *GeneBits512) Pattern() Bin { return b.bin("pattern") }
+
+// Color returns the bits of the color genes.
+func (b *GeneBits512) Color() Bin { return b.bin("color") }
+
+// Part returns the bits of the given part.
+func (b *GeneBits512) Part(partType PartType) Bin { return b.bin(string(partType)) }
+
+func (b *GeneBits256) bin(field string) Bin { return Layout256.readWords(b[:], field) }
+
+func (b *GeneBits256) layout() *Layout { return Layout256 }
+
+func (b *GeneBits512) bin(field string) Bin { return Layout512.readWords(b[:], field) }
+
+func (b *GeneBits512) layout() *Layout { return Layout512 }
+
+// getWordBits extracts up to 64 bits from start to end of the words, where the first bit is the most significant one.
+func getWordBits(words []uint64, start, end int) Bin {
+	if start < 0 || end > len(words)*64 || start > end || end-start > 64 {
+		return Bin{}
+	}
+	var value uint64
+	for i := start; i < end; {
+		offset := i % 64
+		n := 64 - offset
+		if n > end-i {
+			n = end - i
+		}
+		value = value<<uint(n) | words[i/64]<<uint(offset)>>uint(64-n)
+		i += n
+	}
+	return Bin{Value: value, Width: end - start}
+}
+
+// binReader provides the groups of bits needed to decode the genes, as named in the fields of its layout.
+type binReader interface {
+	bin(field string) Bin
+	layout() *Layout
+}
+
+func (gbg *GeneBinGroup) bin(field string) Bin {
+	if bin := gbg.field(field); bin != nil {
+		return parseBin(*bin)
+	}
+	return Bin{}
+}
+
+// layout infers the layout of a grouped binary from the size of its class, since GeneBinGroup does not record it.
+func (gbg *GeneBinGroup) layout() *Layout {
+	if len(gbg.Class) == 5 {
+		return Layout512
+	}
+	return Layout256
+}
@@ -0,0 +1,125 @@
+package agp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeBits(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+	}{
+		{"DEFAULT", "0x11c642400a028ca14a428c20cc011080c61180a0820180604233082"},
+		{"JAPAN_MYSTIC", "0x00080000011c6424c0a028ca14a428c20cc011080c61180a0820180604233082"},
+		{"ZERO_QUALITY", "0x10000000080c144410a0294208a220881040080a0c24180410c3194200200904"},
+		{"HIGH_QUALITY", "0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2"},
+		{"INVALID_PART", "0x11c642400a028ca14a428c20cc011080c61180a0820180604233fff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bits, err := ParseBits(tt.hex)
+			if err != nil {
+				t.Fatalf("DecodeBits() error occured while parsing hex = %v", err)
+				return
+			}
+			got, gotErr := DecodeBits(&bits)
+			want, wantErr := ParseHexDecode(tt.hex)
+			if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(gotErr, wantErr) {
+				t.Fatalf("DecodeBits() got = %v, %v,\nwant %v, %v", got, gotErr, want, wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeBits512(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+	}{
+		{"DEFAULT", "0x00000000000000000040e06102100000000000002801430a00000014288143020000000c300084080000000c1820c00a000000080800c0060000000408618202"},
+		{"JAPAN_MYSTIC_AGAMOGENESIS", "0x180000000000000001008040020c00000000008c106083040000000c086043020000000c2861830a0000018c3061830c0000010c08604302"},
+		{"INVALID_SKIN", "0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c000001ec08604302"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bits, err := ParseBits512(tt.hex)
+			if err != nil {
+				t.Fatalf("DecodeBits512() error occured while parsing hex = %v", err)
+				return
+			}
+			got, gotErr := DecodeBits512(&bits)
+			want, wantErr := ParseHexDecode512(tt.hex)
+			if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(gotErr, wantErr) {
+				t.Fatalf("DecodeBits512() got = %v, %v,\nwant %v, %v", got, gotErr, want, wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneBitsBits(t *testing.T) {
+	bits, _ := ParseBits512("0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302")
+	gbg, _ := ParseHex512("0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302")
+	tests := []struct {
+		name string
+		got  Bin
+		want string
+	}{
+		{"CLASS", bits.Class(), gbg.Class},
+		{"REGION", bits.Region(), gbg.Region},
+		{"TAG", bits.Tag(), gbg.Tag},
+		{"PATTERN", bits.Pattern(), gbg.Pattern},
+		{"COLOR", bits.Color(), gbg.Color},
+		{"EYES", bits.Part(Eyes), gbg.Eyes},
+		{"TAIL", bits.Part(Tail), gbg.Tail},
+		{"ACROSS_WORDS", bits.Bits(60, 70), "0" + gbg.BodySkin + gbg.Pattern[0:5]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.String() != tt.want {
+				t.Fatalf("Bits() got = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinString(t *testing.T) {
+	tests := []struct {
+		name string
+		bin  Bin
+		want string
+	}{
+		{"EMPTY", Bin{}, ""},
+		{"CACHED", Bin{5, 4}, "0101"},
+		{"FORMATTED", Bin{5, 12}, "000000000101"},
+		{"OVERFLOW", Bin{9, 3}, "001"},
+		{"NEGATIVE_WIDTH", Bin{1, -1}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bin.String(); got != tt.want {
+				t.Fatalf("String() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeBits(b *testing.B) {
+	bits, _ := ParseBits("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeBits(&bits); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeBits512(b *testing.B) {
+	bits, _ := ParseBits512("0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeBits512(&bits); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
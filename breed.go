@@ -0,0 +1,146 @@
+package agp
+
+import (
+	"math"
+	"sort"
+)
+
+// Chances of an offspring inheriting each gene of a parent's part. Both parents have equal chances of passing down
+// their genes, so the chances of the two parents add up to 1.
+const (
+	dInheritance  = 0.375
+	r1Inheritance = 0.09375
+	r2Inheritance = 0.03125
+)
+
+// Offspring contains the probability distribution of the genes that an offspring may inherit from its parents.
+type Offspring struct {
+	Class               []ClassOdds `json:"class,omitempty"`
+	Eyes                PartOdds    `json:"eyes,omitempty"`
+	Mouth               PartOdds    `json:"mouth,omitempty"`
+	Ears                PartOdds    `json:"ears,omitempty"`
+	Horn                PartOdds    `json:"horn,omitempty"`
+	Back                PartOdds    `json:"back,omitempty"`
+	Tail                PartOdds    `json:"tail,omitempty"`
+	PureChance          float64     `json:"pureChance,omitempty"`
+	ExpectedGeneQuality float64     `json:"expectedGeneQuality,omitempty"`
+}
+
+// PartOdds stores the chances of each gene appearing on the dominant and recessive genes of an offspring's part.
+type PartOdds struct {
+	D  []GeneOdds `json:"d,omitempty"`
+	R1 []GeneOdds `json:"r1,omitempty"`
+	R2 []GeneOdds `json:"r2,omitempty"`
+}
+
+// GeneOdds holds the chance of an offspring inheriting a single part gene.
+type GeneOdds struct {
+	Gene        PartGene `json:"gene,omitempty"`
+	Probability float64  `json:"probability,omitempty"`
+}
+
+// ClassOdds holds the chance of an offspring being of a given class.
+type ClassOdds struct {
+	Class       Class   `json:"class,omitempty"`
+	Probability float64 `json:"probability,omitempty"`
+}
+
+// Breed computes the probability distribution of the class and the part genes of an offspring of the given parents.
+func Breed(parentA, parentB Genes) Offspring {
+	offspring := Offspring{
+		Class: getClassOdds(parentA.Class, parentB.Class),
+		Eyes:  getPartOdds(parentA.Eyes, parentB.Eyes),
+		Mouth: getPartOdds(parentA.Mouth, parentB.Mouth),
+		Ears:  getPartOdds(parentA.Ears, parentB.Ears),
+		Horn:  getPartOdds(parentA.Horn, parentB.Horn),
+		Back:  getPartOdds(parentA.Back, parentB.Back),
+		Tail:  getPartOdds(parentA.Tail, parentB.Tail),
+	}
+	offspring.PureChance = getPureChance(offspring)
+	offspring.ExpectedGeneQuality = getExpectedGeneQuality(offspring)
+	return offspring
+}
+
+// parts lists the odds of each of the offspring's parts.
+func (o Offspring) parts() []PartOdds {
+	return []PartOdds{o.Eyes, o.Mouth, o.Ears, o.Horn, o.Back, o.Tail}
+}
+
+// getClassOdds computes the chances of the offspring inheriting the class of either parent.
+func getClassOdds(classA, classB Class) []ClassOdds {
+	if classA == classB {
+		return []ClassOdds{{classA, 1}}
+	}
+	odds := []ClassOdds{{classA, 0.5}, {classB, 0.5}}
+	sort.Slice(odds, func(i, j int) bool { return odds[i].Class < odds[j].Class })
+	return odds
+}
+
+// getPartOdds computes the chances of each gene of the parents being inherited by the offspring's part.
+// Each gene of the offspring is inherited independently, so the dominant and recessive genes have the same odds. Each
+// of them gets its own copy, so that changing the odds of one gene leaves the others untouched.
+func getPartOdds(partA, partB Part) PartOdds {
+	odds := getGeneOdds(partA, partB)
+	return PartOdds{odds, append([]GeneOdds(nil), odds...), append([]GeneOdds(nil), odds...)}
+}
+
+// getGeneOdds merges the inheritance chances of the genes of both parents, sorted from the most to the least likely.
+func getGeneOdds(partA, partB Part) []GeneOdds {
+	var odds []GeneOdds
+	index := map[string]int{}
+	for _, part := range []Part{partA, partB} {
+		for _, inherit := range []GeneOdds{{part.D, dInheritance}, {part.R1, r1Inheritance}, {part.R2, r2Inheritance}} {
+			if i, ok := index[inherit.Gene.PartId]; ok {
+				odds[i].Probability += inherit.Probability
+				continue
+			}
+			index[inherit.Gene.PartId] = len(odds)
+			odds = append(odds, inherit)
+		}
+	}
+	sort.SliceStable(odds, func(i, j int) bool {
+		if odds[i].Probability != odds[j].Probability {
+			return odds[i].Probability > odds[j].Probability
+		}
+		return odds[i].Gene.PartId < odds[j].Gene.PartId
+	})
+	return odds
+}
+
+// getClassChance computes the chance of the inherited gene being of the given class. An unknown class never matches.
+func getClassChance(odds []GeneOdds, class Class) float64 {
+	chance := 0.0
+	for _, o := range odds {
+		if class != "" && o.Gene.Class == class {
+			chance += o.Probability
+		}
+	}
+	return chance
+}
+
+// getPureChance computes the chance of all the dominant genes of the offspring matching its class.
+func getPureChance(offspring Offspring) float64 {
+	pureChance := 0.0
+	for _, class := range offspring.Class {
+		chance := class.Probability
+		for _, part := range offspring.parts() {
+			chance *= getClassChance(part.D, class.Class)
+		}
+		pureChance += chance
+	}
+	return pureChance
+}
+
+// getExpectedGeneQuality computes the expected value of the gene quality of the offspring as computed by getGeneQuality.
+func getExpectedGeneQuality(offspring Offspring) float64 {
+	geneQuality := 0.0
+	for _, class := range offspring.Class {
+		for _, part := range offspring.parts() {
+			partQuality := getClassChance(part.D, class.Class) * dQuality
+			partQuality += getClassChance(part.R1, class.Class) * r1Quality
+			partQuality += getClassChance(part.R2, class.Class) * r2Quality
+			geneQuality += class.Probability * partQuality
+		}
+	}
+	return math.Round(geneQuality*100) / 100
+}
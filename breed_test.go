@@ -0,0 +1,57 @@
+package agp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBreed(t *testing.T) {
+	pure, _ := ParseHexDecode("0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2")
+	mixed, _ := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	tests := []struct {
+		name        string
+		parentA     Genes
+		parentB     Genes
+		wantClass   []ClassOdds
+		wantPure    float64
+		wantQuality float64
+	}{
+		{"PURE_PARENTS", pure, pure, []ClassOdds{{Plant, 1}}, 1, 100},
+		{"MIXED_PARENTS", mixed, mixed, []ClassOdds{{Beast, 1}}, 0, 23.96},
+		{"MIXED_CLASSES", pure, mixed, []ClassOdds{{Beast, 0.5}, {Plant, 0.5}}, 0.03207433223724365, 38.54},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Breed(tt.parentA, tt.parentB)
+			if !reflect.DeepEqual(got.Class, tt.wantClass) {
+				t.Fatalf("Breed() class got = %v, want %v", got.Class, tt.wantClass)
+			}
+			if got.PureChance != tt.wantPure {
+				t.Fatalf("Breed() pure chance got = %v, want %v", got.PureChance, tt.wantPure)
+			}
+			if got.ExpectedGeneQuality != tt.wantQuality {
+				t.Fatalf("Breed() expected gene quality got = %v, want %v", got.ExpectedGeneQuality, tt.wantQuality)
+			}
+		})
+	}
+}
+
+func TestGetGeneOdds(t *testing.T) {
+	genes, _ := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	want := []GeneOdds{
+		{PartGene{"eyes-chubby", Beast, "", Eyes, "Chubby", GlobalSkin, ""}, 0.9375},
+		{PartGene{"eyes-blossom", Plant, "", Eyes, "Blossom", GlobalSkin, ""}, 0.0625},
+	}
+	if got := getGeneOdds(genes.Eyes, genes.Eyes); !reflect.DeepEqual(got, want) {
+		t.Fatalf("getGeneOdds() got = %v, want %v", got, want)
+	}
+}
+
+func TestGetPartOdds(t *testing.T) {
+	genes, _ := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	odds := getPartOdds(genes.Eyes, genes.Eyes)
+	odds.D[0].Probability = 0
+	if odds.R1[0].Probability == 0 || odds.R2[0].Probability == 0 {
+		t.Fatalf("getPartOdds() recessive odds changed with the dominant odds = %v", odds)
+	}
+}
@@ -0,0 +1,62 @@
+package agp
+
+// Build lists the PartId desired for each part type. Part types missing from the build can be anything.
+type Build map[PartType]string
+
+// BuildMatch counts the genes of an Axie that match a Build on each slot of its parts.
+type BuildMatch struct {
+	D     int `json:"d"`
+	R1    int `json:"r1"`
+	R2    int `json:"r2"`
+	Parts int `json:"parts"`
+}
+
+// Complete checks whether every dominant gene of the build is matched.
+func (bm BuildMatch) Complete() bool {
+	return bm.D == bm.Parts
+}
+
+// MatchBuild counts the genes of the Axie that match the build on each slot of its parts.
+func MatchBuild(genes Genes, build Build) BuildMatch {
+	match := BuildMatch{Parts: len(build)}
+	for _, p := range genes.parts() {
+		partId, ok := build[p.partType]
+		if !ok {
+			continue
+		}
+		if p.part.D.PartId == partId {
+			match.D++
+		}
+		if p.part.R1.PartId == partId {
+			match.R1++
+		}
+		if p.part.R2.PartId == partId {
+			match.R2++
+		}
+	}
+	return match
+}
+
+// BuildChance computes the chance of an offspring of the given parents having every dominant gene of the build.
+func BuildChance(parentA, parentB Genes, build Build) float64 {
+	chance := 1.0
+	partsA, partsB := parentA.parts(), parentB.parts()
+	for i, p := range partsA {
+		partId, ok := build[p.partType]
+		if !ok {
+			continue
+		}
+		chance *= getPartIdChance(getGeneOdds(p.part, partsB[i].part), partId)
+	}
+	return chance
+}
+
+// getPartIdChance finds the chance of the inherited gene being the given part.
+func getPartIdChance(odds []GeneOdds, partId string) float64 {
+	for _, o := range odds {
+		if o.Gene.PartId == partId {
+			return o.Probability
+		}
+	}
+	return 0
+}
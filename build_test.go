@@ -0,0 +1,51 @@
+package agp
+
+import "testing"
+
+func TestMatchBuild(t *testing.T) {
+	genes, _ := ParseHexDecode("0xd34c44414a028c40023114400802082004130040025280200a0280a")
+	tests := []struct {
+		name         string
+		build        Build
+		want         BuildMatch
+		wantComplete bool
+	}{
+		{"COMPLETE_BUILD", Build{Mouth: "mouth-nut-cracker", Back: "back-ronin", Tail: "tail-nut-cracker"}, BuildMatch{3, 1, 2, 3}, true},
+		{"PARTIAL_BUILD", Build{Eyes: "eyes-chubby", Tail: "tail-nut-cracker"}, BuildMatch{1, 2, 1, 2}, false},
+		{"EMPTY_BUILD", Build{}, BuildMatch{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchBuild(genes, tt.build)
+			if got != tt.want {
+				t.Fatalf("MatchBuild() got = %v, want %v", got, tt.want)
+			}
+			if got.Complete() != tt.wantComplete {
+				t.Fatalf("Complete() got = %v, want %v", got.Complete(), tt.wantComplete)
+			}
+		})
+	}
+}
+
+func TestBuildChance(t *testing.T) {
+	genes, _ := ParseHexDecode("0xd34c44414a028c40023114400802082004130040025280200a0280a")
+	build := Build{Mouth: "mouth-nut-cracker", Back: "back-ronin", Tail: "tail-nut-cracker"}
+	tests := []struct {
+		name    string
+		parentB Genes
+		build   Build
+		want    float64
+	}{
+		{"SAME_PARENTS", genes, build, 0.609375},
+		{"SINGLE_PARENT", Genes{}, build, 0.076171875},
+		{"MISSING_PART", genes, Build{Eyes: "eyes-zeal"}, 0},
+		{"EMPTY_BUILD", Genes{}, Build{}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildChance(genes, tt.parentB, tt.build); got != tt.want {
+				t.Fatalf("BuildChance() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package agp
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed assets/cards.json
+var cardsJson []byte
+
+// cardsJSON holds the content of the cards.json file, keyed by the id of the part granting the card.
+type cardsJSON map[string]Card
+
+// getCardsJSON unmarshalls the content of the cards.json file into a cardsJSON object.
+func getCardsJSON() (cardsJSON, error) {
+	var ret cardsJSON
+	err := json.Unmarshal(cardsJson, &ret)
+	return ret, err
+}
+
+// Card is the battle card granted by a dominant part gene. Special variants of a part, such as its mystic or japanese
+// variant, grant the same card as the part itself, so PartId is the id of the part that the card is listed under.
+type Card struct {
+	PartId string   `json:"partId,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Energy int      `json:"energy"`
+	Attack int      `json:"attack"`
+	Shield int      `json:"shield"`
+	Effect string   `json:"effect,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// cardParts lists the parts granting the fighting cards of an Axie, in the order returned by Cards.
+var cardParts = []PartType{Mouth, Horn, Back, Tail}
+
+// Cards lists the fighting cards granted by the dominant genes of the mouth, horn, back and tail of an Axie. An error
+// wrapping ErrUnknownCard is returned when any of these parts has no card in the cards.json file.
+func Cards(genes Genes) ([]Card, error) {
+	c, err := getCatalog()
+	if err != nil {
+		return nil, err
+	}
+	return c.Cards(genes)
+}
+
+// Cards lists the fighting cards of an Axie as done by Cards, using the cards of the catalog.
+func (c *Catalog) Cards(genes Genes) ([]Card, error) {
+	parts := map[PartType]Part{Mouth: genes.Mouth, Horn: genes.Horn, Back: genes.Back, Tail: genes.Tail}
+	cards := make([]Card, 0, len(cardParts))
+	for _, partType := range cardParts {
+		card, err := c.getCard(parts[partType].D.PartId)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// getCard finds the card granted by the given part, falling back to the card of the part that it is a variant of.
+// The tags are copied so that the catalog cannot be modified through the returned card.
+func (c *Catalog) getCard(partId string) (Card, error) {
+	card, ok := c.cards[partId]
+	if !ok {
+		if card, ok = c.cards[c.variants[partId]]; !ok {
+			return Card{}, fmt.Errorf("%w: %s", ErrUnknownCard, partId)
+		}
+	}
+	card.Tags = append([]string(nil), card.Tags...)
+	return card, nil
+}
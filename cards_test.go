@@ -0,0 +1,63 @@
+package agp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCards(t *testing.T) {
+	part := func(partId string) Part {
+		return Part{D: PartGene{PartId: partId}}
+	}
+	beast := Genes{Mouth: part("mouth-nut-cracker"), Horn: part("horn-dual-blade"), Back: part("back-ronin"), Tail: part("tail-nut-cracker")}
+	mystic := beast
+	mystic.Back = part("back-hasagi")
+	mystic.Horn = part("horn-winter-branch")
+	unknown := beast
+	unknown.Tail = part("tail-unknown")
+	tests := []struct {
+		name    string
+		genes   Genes
+		want    []string
+		wantErr error
+	}{
+		{"VALID_CARDS", beast, []string{"Nut Crack", "Sinister Strike", "Single Combat", "Nut Throw"}, nil},
+		{"SPECIAL_VARIANTS", mystic, []string{"Nut Crack", "Branch Charge", "Single Combat", "Nut Throw"}, nil},
+		{"UNKNOWN_CARD", unknown, nil, ErrUnknownCard},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, err := Cards(tt.genes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Cards() error = %v, want %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, card := range cards {
+				got = append(got, card.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Cards() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCard(t *testing.T) {
+	c, err := getCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Card{"back-ronin", "Single Combat", 1, 75, 0, "Guaranteed critical strike when comboed with at least 2 other cards.", []string{"attack", "critical", "combo"}}
+	got, err := c.getCard("back-hasagi")
+	if err != nil {
+		t.Fatalf("getCard() unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("getCard() got = %v, want %v", got, want)
+	}
+	got.Tags[0] = "modified"
+	if again, _ := c.getCard("back-ronin"); !reflect.DeepEqual(again, want) {
+		t.Fatalf("getCard() got = %v after modifying a returned card, want %v", again, want)
+	}
+}
@@ -0,0 +1,375 @@
+package agp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Catalog holds the traits, parts, patterns, colors, stats and cards used to decode the genes, as listed in the
+// traits.json, parts.json, patterns.json, colors.json, stats.json and cards.json files. The catalog of the embedded
+// files is used by default, while catalogs loaded at runtime are used through WithCatalog or a Decoder. A catalog is
+// never modified once it is built, so it can be shared between goroutines.
+type Catalog struct {
+	traits   traitsJSON
+	parts    partsJSON
+	patterns patternsJSON
+	// traitIndex maps the bits of each part into the names of its variants, keyed by skin.
+	traitIndex map[traitKey]map[string]string
+	// partIndex maps the name of each part into its part gene.
+	partIndex map[partKey]PartGene
+	// variantIndex maps the id of each part into the names of the variants sharing its bits, keyed by skin.
+	variantIndex map[string]map[string]string
+	// shapeBits maps the size of a pattern gene into the number of bits holding the body shape.
+	shapeBits map[int]int
+	// shapeIndex maps the body shape bits of each size of pattern gene into the body shape.
+	shapeIndex map[shapeKey]BodyShape
+	colors     colorsJSON
+	// colorIndex maps the bits of the color genes of each class into their colors.
+	colorIndex map[colorKey]Color
+	stats      statsJSON
+	// cards maps the id of each part into its card.
+	cards cardsJSON
+	// variants maps the id of each special variant of a part into the id of the part itself.
+	variants map[string]string
+}
+
+// traitKey identifies a part within the traits.json file.
+type traitKey struct {
+	class    Class
+	partType PartType
+	bin      Bin
+}
+
+// partKey identifies a part by its name.
+type partKey struct {
+	partType PartType
+	name     string
+}
+
+// shapeKey identifies a body shape within the patterns.json file.
+type shapeKey struct {
+	geneSize int
+	bin      Bin
+}
+
+// colorKey identifies a color within the colors.json file.
+type colorKey struct {
+	class Class
+	bin   Bin
+}
+
+var (
+	defaultCatalog     *Catalog
+	defaultCatalogErr  error
+	defaultCatalogOnce sync.Once
+)
+
+// getCatalog lazily builds the catalog from the embedded traits.json, parts.json, patterns.json, colors.json, stats.json and cards.json files.
+func getCatalog() (*Catalog, error) {
+	defaultCatalogOnce.Do(func() {
+		defaultCatalog, defaultCatalogErr = newCatalog()
+	})
+	return defaultCatalog, defaultCatalogErr
+}
+
+// DefaultCatalog returns the catalog of the embedded traits.json, parts.json, patterns.json, colors.json, stats.json and
+// cards.json files, which is used unless another catalog is given.
+func DefaultCatalog() (*Catalog, error) {
+	return getCatalog()
+}
+
+// newCatalog unmarshalls the embedded traits.json, parts.json, patterns.json, colors.json, stats.json and cards.json files into a catalog.
+func newCatalog() (*Catalog, error) {
+	files, err := getCatalogFiles()
+	if err != nil {
+		return nil, err
+	}
+	return files.build()
+}
+
+// catalogFiles holds the contents of the files of a catalog. Files left out of the JSON document read by ReadCatalog
+// are nil.
+type catalogFiles struct {
+	Traits   traitsJSON   `json:"traits"`
+	Parts    partsJSON    `json:"parts"`
+	Patterns patternsJSON `json:"patterns"`
+	Colors   colorsJSON   `json:"colors"`
+	Stats    *statsJSON   `json:"stats"`
+	Cards    cardsJSON    `json:"cards"`
+}
+
+// getCatalogFiles unmarshalls the contents of the embedded files of the catalog.
+func getCatalogFiles() (catalogFiles, error) {
+	var files catalogFiles
+	var err error
+	if files.Traits, err = getTraitsJSON(); err != nil {
+		return files, err
+	}
+	if files.Parts, err = getPartsJSON(); err != nil {
+		return files, err
+	}
+	if files.Patterns, err = getPatternsJSON(); err != nil {
+		return files, err
+	}
+	if files.Colors, err = getColorsJSON(); err != nil {
+		return files, err
+	}
+	stats, err := getStatsJSON()
+	if err != nil {
+		return files, err
+	}
+	files.Stats = &stats
+	if files.Cards, err = getCardsJSON(); err != nil {
+		return files, err
+	}
+	return files, nil
+}
+
+// withDefaults fills the files left out with the contents of the embedded files.
+func (files catalogFiles) withDefaults() (catalogFiles, error) {
+	defaults, err := getCatalogFiles()
+	if err != nil {
+		return files, err
+	}
+	if files.Traits == nil {
+		files.Traits = defaults.Traits
+	}
+	if files.Parts == nil {
+		files.Parts = defaults.Parts
+	}
+	if files.Patterns == nil {
+		files.Patterns = defaults.Patterns
+	}
+	if files.Colors == nil {
+		files.Colors = defaults.Colors
+	}
+	if files.Stats == nil {
+		files.Stats = defaults.Stats
+	}
+	if files.Cards == nil {
+		files.Cards = defaults.Cards
+	}
+	return files, nil
+}
+
+// ReadCatalog builds a catalog from a JSON document holding the contents of the catalog files under the "traits",
+// "parts", "patterns", "colors", "stats" and "cards" keys. Files left out of the document are replaced with the
+// embedded files. Use Merge to add the entries of the catalog to the embedded ones instead.
+func ReadCatalog(r io.Reader) (*Catalog, error) {
+	var files catalogFiles
+	if err := json.NewDecoder(r).Decode(&files); err != nil {
+		return nil, fmt.Errorf("invalid catalog: %w", err)
+	}
+	files, err := files.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	return files.build()
+}
+
+// LoadCatalog builds a catalog from the JSON document read by ReadCatalog stored at the given path.
+func LoadCatalog(path string) (*Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadCatalog(f)
+}
+
+// LoadCatalogFS builds a catalog from the traits.json, parts.json, patterns.json, colors.json, stats.json and cards.json
+// files at the root of the file system, laid out like the assets directory of this module. Files missing from the file
+// system are replaced with the embedded files.
+func LoadCatalogFS(fsys fs.FS) (*Catalog, error) {
+	var files catalogFiles
+	for name, v := range map[string]interface{}{
+		"traits.json": &files.Traits, "parts.json": &files.Parts, "patterns.json": &files.Patterns,
+		"colors.json": &files.Colors, "stats.json": &files.Stats, "cards.json": &files.Cards,
+	} {
+		data, err := fs.ReadFile(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			return nil, fmt.Errorf("invalid catalog file %s: %w", name, err)
+		}
+	}
+	files, err := files.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	return files.build()
+}
+
+// Merge builds a catalog holding the entries of both catalogs. Entries of the other catalog replace the entries of
+// this catalog with the same key, such as the variants of a part, a part by its id, or the color of a class by its
+// bits. Neither catalog is modified.
+func (c *Catalog) Merge(other *Catalog) (*Catalog, error) {
+	files := catalogFiles{
+		Traits: traitsJSON{}, Parts: partsJSON{}, Patterns: patternsJSON{}, Colors: colorsJSON{},
+		Stats: &statsJSON{Base: map[Class]BattleStats{}, Parts: map[Class]BattleStats{}}, Cards: cardsJSON{},
+	}
+	for _, source := range []*Catalog{c, other} {
+		for class, partTypes := range source.traits {
+			if files.Traits[class] == nil {
+				files.Traits[class] = map[PartType]map[string]map[string]string{}
+			}
+			for partType, bins := range partTypes {
+				if files.Traits[class][partType] == nil {
+					files.Traits[class][partType] = map[string]map[string]string{}
+				}
+				for bStr, names := range bins {
+					if files.Traits[class][partType][bStr] == nil {
+						files.Traits[class][partType][bStr] = map[string]string{}
+					}
+					for skin, name := range names {
+						files.Traits[class][partType][bStr][skin] = name
+					}
+				}
+			}
+		}
+		for partId, partGene := range source.parts {
+			files.Parts[partId] = partGene
+		}
+		for geneSize, pattern := range source.patterns {
+			files.Patterns[geneSize] = pattern
+		}
+		for class, classColors := range source.colors {
+			if files.Colors[class] == nil {
+				files.Colors[class] = map[string]Color{}
+			}
+			for bStr, color := range classColors {
+				files.Colors[class][bStr] = color
+			}
+		}
+		for class, stats := range source.stats.Base {
+			files.Stats.Base[class] = stats
+		}
+		for class, stats := range source.stats.Parts {
+			files.Stats.Parts[class] = stats
+		}
+		for partId, card := range source.cards {
+			files.Cards[partId] = card
+		}
+	}
+	return files.build()
+}
+
+// build indexes the contents of the files into a catalog. The files are copied where needed, so that the catalog does
+// not share any entry that it modifies.
+func (files catalogFiles) build() (*Catalog, error) {
+	c := &Catalog{traits: files.Traits, parts: files.Parts, patterns: files.Patterns, traitIndex: map[traitKey]map[string]string{}, variantIndex: map[string]map[string]string{},
+		partIndex: map[partKey]PartGene{}, shapeBits: map[int]int{}, shapeIndex: map[shapeKey]BodyShape{}, colors: files.Colors,
+		colorIndex: map[colorKey]Color{}, stats: *files.Stats, cards: cardsJSON{}, variants: map[string]string{}}
+	for partId, card := range files.Cards {
+		card.PartId = partId
+		c.cards[partId] = card
+	}
+	for class, classColors := range files.Colors {
+		for bStr, color := range classColors {
+			color.Bits = bStr
+			c.colorIndex[colorKey{class, parseBin(bStr)}] = color
+		}
+	}
+	for geneSize, pattern := range files.Patterns {
+		size, err := strconv.Atoi(geneSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern gene size %q: %w", geneSize, err)
+		}
+		c.shapeBits[size] = pattern.ShapeBits
+		for bStr, shape := range pattern.Shapes {
+			if len(bStr) != pattern.ShapeBits {
+				return nil, fmt.Errorf("invalid body shape bits %q for %d bit pattern genes", bStr, size)
+			}
+			c.shapeIndex[shapeKey{size, parseBin(bStr)}] = shape
+		}
+	}
+	for class, partTypes := range files.Traits {
+		for partType, bins := range partTypes {
+			for bStr, names := range bins {
+				c.traitIndex[traitKey{class, partType, parseBin(bStr)}] = names
+				for skin, name := range names {
+					if skin != string(GlobalSkin) {
+						c.variants[getPartId(partType, name)] = getPartId(partType, names[string(GlobalSkin)])
+					}
+				}
+				for _, name := range names {
+					c.variantIndex[getPartId(partType, name)] = names
+				}
+				for _, name := range names {
+					if partGene, ok := files.Parts[getPartId(partType, name)]; ok {
+						c.partIndex[partKey{partType, name}] = partGene
+					}
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+// getPartName finds the name of the part with the given bits, using the variant of the given skin when available.
+func (c *Catalog) getPartName(class Class, partType PartType, partBin Bin, skin PartSkin) (string, error) {
+	if partName := resolvePartName(c.traitIndex[traitKey{class, partType, partBin}], skin); partName != "" {
+		return partName, nil
+	}
+	return "", &DecodeError{Err: fmt.Errorf("%w: %s %s", ErrUnknownPart, class, partType), Field: string(partType), Bits: partBin.String()}
+}
+
+// getPartGeneByName finds the part gene with the given name.
+func (c *Catalog) getPartGeneByName(partType PartType, partName string) (PartGene, error) {
+	if partGene, ok := c.partIndex[partKey{partType, partName}]; ok {
+		return partGene, nil
+	}
+	// Fall back to the id of the part in case the name is formatted differently from the traits.json file.
+	partId := getPartId(partType, partName)
+	if partGene, ok := c.parts[partId]; ok {
+		return partGene, nil
+	}
+	return PartGene{}, &DecodeError{Err: fmt.Errorf("%w: %s", ErrUnknownPart, partId), Field: string(partType)}
+}
+
+// getPartGene finds the part gene with the given bits, using the variant of the given skin when available.
+// The skin is kept on the part gene even when the part has no such variant.
+func (c *Catalog) getPartGene(class Class, partType PartType, partBin Bin, skin PartSkin) (PartGene, error) {
+	partName, err := c.getPartName(class, partType, partBin, skin)
+	if err != nil {
+		return PartGene{}, err
+	}
+	partGene, err := c.getPartGeneByName(partType, partName)
+	if err != nil {
+		return PartGene{}, err
+	}
+	partGene.Skin = skin
+	return partGene, nil
+}
+
+// getBodyPattern splits a single pattern gene into its body shape and pattern. The shape is marked as UnknownShape when
+// its bits are not listed.
+func (c *Catalog) getBodyPattern(gene Bin) (BodyPattern, error) {
+	shapeBits, ok := c.shapeBits[gene.Width]
+	if !ok {
+		return BodyPattern{}, &DecodeError{Err: fmt.Errorf("%w: no body shapes for %d bit genes", ErrUnknownPattern, gene.Width), Field: "pattern", Bits: gene.String()}
+	}
+	shape, ok := c.shapeIndex[shapeKey{gene.Width, gene.Slice(0, shapeBits)}]
+	if !ok {
+		shape = UnknownShape
+	}
+	return BodyPattern{shape, int(gene.Slice(shapeBits, gene.Width).Value)}, nil
+}
+
+// getColor finds the color of the class with the given bits, which is marked as UnknownColor when it is not listed.
+func (c *Catalog) getColor(class Class, colorBin Bin) Color {
+	if color, ok := c.colorIndex[colorKey{class, colorBin}]; ok {
+		return color
+	}
+	return Color{Bits: colorBin.String(), Hex: UnknownColor, Name: UnknownColor}
+}
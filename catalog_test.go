@@ -0,0 +1,210 @@
+package agp
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGetCatalog(t *testing.T) {
+	var wg sync.WaitGroup
+	catalogs := make([]*Catalog, 8)
+	for i := range catalogs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			catalogs[i], _ = getCatalog()
+		}(i)
+	}
+	wg.Wait()
+	for _, c := range catalogs {
+		if c == nil || c != catalogs[0] {
+			t.Fatalf("getCatalog() expected the same catalog to be shared")
+		}
+	}
+	if len(catalogs[0].traits) == 0 || len(catalogs[0].parts) == 0 {
+		t.Fatalf("getCatalog() returned an empty catalog")
+	}
+}
+
+func TestCatalogGetPartGeneByName(t *testing.T) {
+	c, _ := DefaultCatalog()
+	type args struct {
+		partType PartType
+		partName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    PartGene
+		wantErr bool
+	}{
+		{"VALID_PART_GENE", args{Ears, "Nut Cracker"}, PartGene{"ears-nut-cracker", Beast, "", Ears, "Nut Cracker", "", ""}, false},
+		{"INVALID_COMBINATION", args{Ears, "Chubby"}, PartGene{}, true},
+		{"INVALID_PART_NAME", args{Ears, "Ballon Mouth"}, PartGene{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.getPartGeneByName(tt.args.partType, tt.args.partName)
+			if err == nil && tt.wantErr {
+				t.Fatalf("getPartGeneByName() expected an error")
+				return
+			}
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("getPartGeneByName() unexpected error = %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("getPartGeneByName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogGetPartName(t *testing.T) {
+	c, _ := DefaultCatalog()
+	type args struct {
+		class    Class
+		partType PartType
+		partBin  string
+		partSkin PartSkin
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{"VALID_PART_NAME", args{Beast, Ears, "001000", GlobalSkin}, "Zen", false},
+		{"XMAS_PART_NAME", args{Beast, Ears, "000110", Xmas1}, "Merry Lamb", false},
+		{"INVALID_PART_BIN", args{Beast, Ears, "100100", GlobalSkin}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.getPartName(tt.args.class, tt.args.partType, parseBin(tt.args.partBin), tt.args.partSkin)
+			if err == nil && tt.wantErr {
+				t.Fatalf("getPartName() expected an error")
+				return
+			}
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("getPartName() unexpected error = %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("getPartName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkParseHexDecode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseHexDecode512(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseHexDecode512("0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPartGene(b *testing.B) {
+	c, _ := DefaultCatalog()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.getPartGene(Beast, Ears, parseBin("001000"), GlobalSkin); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetPartGeneUnindexed measures the same lookup as BenchmarkGetPartGene when the catalog files are
+// unmarshalled on every call, as they were before the catalog was introduced.
+func BenchmarkGetPartGeneUnindexed(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		traits, _ := getTraitsJSON()
+		name := resolvePartName(traits[Beast][Ears]["001000"], GlobalSkin)
+		parts, _ := getPartsJSON()
+		if _, ok := parts[getPartId(Ears, name)]; !ok {
+			b.Fatal("part not found")
+		}
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	const custom = `{
+		"traits": {"beast": {"eyes": {"001100": {"global": "New Sight"}}}},
+		"parts": {"eyes-new-sight": {"class": "beast", "name": "New Sight", "partId": "eyes-new-sight", "specialGenes": "", "type": "eyes"}}
+	}`
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := ioutil.WriteFile(path, []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fromPath, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+	var files struct {
+		Traits json.RawMessage `json:"traits"`
+		Parts  json.RawMessage `json:"parts"`
+	}
+	_ = json.Unmarshal([]byte(custom), &files)
+	fromFS, err := LoadCatalogFS(fstest.MapFS{"traits.json": {Data: files.Traits}, "parts.json": {Data: files.Parts}})
+	if err != nil {
+		t.Fatalf("LoadCatalogFS() error = %v", err)
+	}
+	defaults, _ := DefaultCatalog()
+	merged, err := defaults.Merge(fromPath)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	gbg, _ := ParseHex("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	gbg.Eyes = gbg.Eyes[:6] + "001100" + gbg.Eyes[12:]
+	want, _ := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	want.Eyes.D = PartGene{"eyes-new-sight", Beast, "", Eyes, "New Sight", GlobalSkin, ""}
+	tests := []struct {
+		name    string
+		catalog *Catalog
+		wantErr error
+	}{
+		{"DEFAULT_CATALOG", defaults, ErrUnknownPart},
+		{"MERGED_CATALOG", merged, nil},
+		// Substituted catalogs only know about their own parts.
+		{"SUBSTITUTED_CATALOG", fromPath, ErrUnknownPart},
+		{"SUBSTITUTED_CATALOG_FS", fromFS, ErrUnknownPart},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewDecoder(tt.catalog).Decode(&gbg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, want) {
+				t.Fatalf("Decode() got = %v, want %v", got, want)
+			}
+			if _, err := Decode(&gbg, WithCatalog(tt.catalog)); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() with catalog error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := ReadCatalog(strings.NewReader("{")); err == nil {
+		t.Fatalf("ReadCatalog() expected an error")
+	}
+	if _, err := defaults.getPartName(Beast, Eyes, parseBin("001100"), GlobalSkin); err == nil {
+		t.Fatalf("Merge() modified the default catalog")
+	}
+}
@@ -0,0 +1,270 @@
+// Command agp decodes the hex representation of Axie genes into a human readable format.
+//
+// Usage:
+//
+//	agp [-o text|json|ndjson] [-f file]... [hex]...
+//
+// Genes are read from the arguments and from each file given with -f, one hex per line. When neither is given, the
+// genes are read from the standard input instead. Both 256 and 512 bit genes are supported and detected automatically.
+//
+// The command exits with status 1 when any of the genes cannot be decoded or any of the files cannot be read, and with
+// status 2 on invalid usage.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/shanemaglangit/agp"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// fileList collects the values of a flag that may be given multiple times.
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// result holds the outcome of decoding a single input.
+type result struct {
+	Source string         `json:"source"`
+	Line   int            `json:"line,omitempty"`
+	Hex    string         `json:"hex"`
+	Format agp.GeneFormat `json:"format,omitempty"`
+	Genes  *agp.Genes     `json:"genes,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// input is a single hex to be decoded along with where it was read from.
+type input struct {
+	source string
+	line   int
+	hex    string
+}
+
+// run executes the command and returns its exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("agp", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", "text", "output format: text, json or ndjson")
+	var files fileList
+	fs.Var(&files, "f", "file containing newline-delimited genes, use - for the standard input (repeatable)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: agp [-o text|json|ndjson] [-f file]... [hex]...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	w, err := newWriter(*output, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, "agp:", err)
+		fs.Usage()
+		return 2
+	}
+
+	status := 0
+	emit := func(in input) error {
+		res := decode(in)
+		if res.Error != "" {
+			status = 1
+			if res.Line > 0 {
+				fmt.Fprintf(stderr, "agp: %s:%d: %s\n", res.Source, res.Line, res.Error)
+			} else {
+				fmt.Fprintf(stderr, "agp: %s: %s\n", res.Source, res.Error)
+			}
+		}
+		return w.write(res)
+	}
+	// Inputs that cannot be read are reported without stopping the others, so the results are always flushed.
+	for _, hex := range fs.Args() {
+		if err := emit(input{source: "arg", hex: hex}); err != nil {
+			fmt.Fprintln(stderr, "agp:", err)
+			status = 1
+		}
+	}
+	if len(fs.Args()) == 0 && len(files) == 0 {
+		files = append(files, "-")
+	}
+	for _, file := range files {
+		if err := readLines(file, stdin, emit); err != nil {
+			fmt.Fprintln(stderr, "agp:", err)
+			status = 1
+		}
+	}
+	if err := w.flush(); err != nil {
+		fmt.Fprintln(stderr, "agp:", err)
+		return 1
+	}
+	return status
+}
+
+// readLines passes each non-blank line of the given file to fn.
+func readLines(file string, stdin io.Reader, fn func(input) error) error {
+	r, source := stdin, "stdin"
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r, source = f, file
+	}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		hex := strings.TrimSpace(scanner.Text())
+		if hex == "" {
+			continue
+		}
+		if err := fn(input{source: source, line: line, hex: hex}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// decode parses the hex of the input into genes.
+func decode(in input) result {
+	res := result{Source: in.source, Line: in.line, Hex: in.hex}
+	genes, format, err := agp.ParseHexDecodeAuto(in.hex)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Format = format
+	res.Genes = &genes
+	return res
+}
+
+// writer renders the results in one of the output formats.
+type writer interface {
+	write(res result) error
+	flush() error
+}
+
+// newWriter creates the writer for the given output format.
+func newWriter(output string, out io.Writer) (writer, error) {
+	switch output {
+	case "text":
+		return &textWriter{out: out}, nil
+	case "json":
+		return &jsonWriter{out: out, results: []result{}}, nil
+	case "ndjson":
+		return &ndjsonWriter{enc: json.NewEncoder(out)}, nil
+	}
+	return nil, errors.New(fmt.Sprint("unknown output format: ", output))
+}
+
+// textWriter renders each result as an aligned block of text.
+type textWriter struct {
+	out     io.Writer
+	written bool
+}
+
+func (w *textWriter) write(res result) error {
+	if res.Genes == nil {
+		return nil
+	}
+	if w.written {
+		fmt.Fprintln(w.out)
+	}
+	w.written = true
+	g := res.Genes
+	tw := tabwriter.NewWriter(w.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Hex:\t%s (%d bit)\n", res.Hex, res.Format)
+	fmt.Fprintf(tw, "Class:\t%s\n", g.Class)
+	fmt.Fprintf(tw, "Region:\t%s\n", g.Region)
+	fmt.Fprintf(tw, "Tag:\t%s\n", orNone(string(g.Tag)))
+	fmt.Fprintf(tw, "Body Skin:\t%s\n", orNone(string(g.BodySkin)))
+	fmt.Fprintf(tw, "Pattern:\t%s\t%s\t%s\n", g.Pattern.D, g.Pattern.R1, g.Pattern.R2)
+	fmt.Fprintf(tw, "Body:\t%s\t%s\t%s\n", bodyPattern(g.Body.D), bodyPattern(g.Body.R1), bodyPattern(g.Body.R2))
+	fmt.Fprintf(tw, "Color:\t%s\t%s\t%s\n", color(g.Palette.D), color(g.Palette.R1), color(g.Palette.R2))
+	for _, p := range []struct {
+		name string
+		part agp.Part
+	}{{"Eyes", g.Eyes}, {"Ears", g.Ears}, {"Horn", g.Horn}, {"Mouth", g.Mouth}, {"Back", g.Back}, {"Tail", g.Tail}} {
+		fmt.Fprintf(tw, "%s:\t%s\t%s\t%s", p.name, partGene(p.part.D), partGene(p.part.R1), partGene(p.part.R2))
+		if p.part.Mystic {
+			fmt.Fprint(tw, "\tmystic")
+		}
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintf(tw, "Gene Quality:\t%.2f\n", g.GeneQuality)
+	return tw.Flush()
+}
+
+func (w *textWriter) flush() error {
+	return nil
+}
+
+// partGene formats a part gene as its name followed by its class.
+func partGene(gene agp.PartGene) string {
+	return fmt.Sprintf("%s (%s)", gene.Name, gene.Class)
+}
+
+// color formats a color as its name followed by its hex.
+func color(c agp.Color) string {
+	if c.Hex == agp.UnknownColor {
+		return fmt.Sprintf("%s (%s)", c.Name, c.Bits)
+	}
+	return fmt.Sprintf("%s (%s)", c.Name, c.Hex)
+}
+
+// bodyPattern formats a body pattern as its shape followed by the index of its pattern.
+func bodyPattern(body agp.BodyPattern) string {
+	return fmt.Sprintf("%s #%d", body.Shape, body.Pattern)
+}
+
+// orNone replaces empty values with a placeholder.
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// jsonWriter collects the results and renders them as a single JSON array.
+type jsonWriter struct {
+	out     io.Writer
+	results []result
+}
+
+func (w *jsonWriter) write(res result) error {
+	w.results = append(w.results, res)
+	return nil
+}
+
+func (w *jsonWriter) flush() error {
+	enc := json.NewEncoder(w.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(w.results)
+}
+
+// ndjsonWriter renders each result as a JSON object on its own line.
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (w *ndjsonWriter) write(res result) error {
+	return w.enc.Encode(res)
+}
+
+func (w *ndjsonWriter) flush() error {
+	return nil
+}
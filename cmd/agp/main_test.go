@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantStatus int
+		wantLines  int
+	}{
+		{"ARGS", []string{"-o", "ndjson", "0x11c642400a028ca14a428c20cc011080c61180a0820180604233082"}, "", 0, 1},
+		{"STDIN", []string{"-o", "ndjson"}, "0x11c642400a028ca14a428c20cc011080c61180a0820180604233082\n\n0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2\n", 0, 2},
+		{"INVALID_LINE", []string{"-o", "ndjson"}, "0x11c642400a028ca14a428c20cc011080c61180a0820180604233082\nbad\n", 1, 2},
+		{"INVALID_OUTPUT", []string{"-o", "xml"}, "", 2, 0},
+		{"MISSING_FILE", []string{"-f", "missing.txt"}, "", 1, 0},
+		{"MISSING_FILE_AFTER_STDIN", []string{"-o", "ndjson", "-f", "-", "-f", "missing.txt"}, "0x11c642400a028ca14a428c20cc011080c61180a0820180604233082\n", 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if status != tt.wantStatus {
+				t.Fatalf("run() status = %v, want %v, stderr = %s", status, tt.wantStatus, stderr.String())
+			}
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+			if stdout.Len() == 0 {
+				lines = nil
+			}
+			if len(lines) != tt.wantLines {
+				t.Fatalf("run() lines = %v, want %v", len(lines), tt.wantLines)
+			}
+			for _, line := range lines {
+				var res result
+				if err := json.Unmarshal([]byte(line), &res); err != nil {
+					t.Fatalf("run() invalid ndjson line = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"-o", "json", "-f", "-", "-f", "missing.txt"}, strings.NewReader("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082\n"), &stdout, &stderr)
+	if status != 1 {
+		t.Fatalf("run() status = %v, want %v", status, 1)
+	}
+	var results []result
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("run() invalid json = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("run() results = %v, want %v", len(results), 1)
+	}
+}
@@ -0,0 +1,19 @@
+package agp
+
+import (
+	_ "embed"
+	"encoding/json"
+)
+
+//go:embed assets/colors.json
+var colorsJson []byte
+
+// colorsJSON holds the content of the colors.json file, keyed by class and by the bits of a single color gene.
+type colorsJSON map[Class]map[string]Color
+
+// getColorsJSON unmarshalls the content of the colors.json file into a colorsJSON object.
+func getColorsJSON() (colorsJSON, error) {
+	var ret colorsJSON
+	err := json.Unmarshal(colorsJson, &ret)
+	return ret, err
+}
@@ -0,0 +1,142 @@
+package agp
+
+// Decoder decodes genes using its own catalog and options, so that services can pin the catalog that they decode with.
+// Genes are encoded back using the same catalog.
+// A Decoder can be shared between goroutines.
+type Decoder struct {
+	opts decodeOptions
+}
+
+// NewDecoder creates a decoder that uses the given catalog, along with options applied to every decoded gene. The
+// catalog of the embedded files is used when the catalog is nil.
+func NewDecoder(catalog *Catalog, opts ...DecodeOption) *Decoder {
+	o := newDecodeOptions(opts)
+	if catalog != nil {
+		o.catalog = catalog
+	}
+	return &Decoder{o}
+}
+
+// Catalog returns the catalog used by the decoder.
+func (d *Decoder) Catalog() (*Catalog, error) {
+	return d.opts.getCatalog()
+}
+
+// ParseHexDecode parses a given 256 hex into a Gene object, as done by ParseHexDecode.
+func (d *Decoder) ParseHexDecode(hex string) (Genes, error) {
+	gbg, err := ParseHex(hex)
+	if err != nil {
+		return Genes{}, err
+	}
+	return d.Decode(&gbg)
+}
+
+// ParseHexDecode512 parses a given 512 hex into a Gene object, as done by ParseHexDecode512.
+func (d *Decoder) ParseHexDecode512(hex string) (Genes, error) {
+	gbg, err := ParseHex512(hex)
+	if err != nil {
+		return Genes{}, err
+	}
+	return d.Decode512(&gbg)
+}
+
+// ParseHexDecodeAuto parses a given 256 or 512 hex into a Gene object, as done by ParseHexDecodeAuto.
+func (d *Decoder) ParseHexDecodeAuto(hex string) (Genes, GeneFormat, error) {
+	format, err := DetectFormat(hex)
+	if err != nil {
+		return Genes{}, format, err
+	}
+	if format == Format512 {
+		genes, err := d.ParseHexDecode512(hex)
+		return genes, format, err
+	}
+	genes, err := d.ParseHexDecode(hex)
+	return genes, format, err
+}
+
+// Decode parses the grouped binary of the 256 bit format into a Gene object.
+func (d *Decoder) Decode(gbg *GeneBinGroup) (Genes, error) {
+	return d.DecodeLayout(gbg, Layout256)
+}
+
+// Decode512 parses the grouped binary of the 512 bit format into a Gene object.
+func (d *Decoder) Decode512(gbg *GeneBinGroup) (Genes, error) {
+	return d.DecodeLayout(gbg, Layout512)
+}
+
+// DecodeLayout parses the grouped binary of the given layout into a Gene object.
+func (d *Decoder) DecodeLayout(gbg *GeneBinGroup, layout *Layout) (Genes, error) {
+	if err := layout.registered(); err != nil {
+		return Genes{}, err
+	}
+	return decode(layoutGroup{gbg, layout}, d.opts)
+}
+
+// ParseHexDecodeLenient parses a given 256 or 512 hex into a Gene object in lenient mode, as done by
+// ParseHexDecodeLenient.
+func (d *Decoder) ParseHexDecodeLenient(hex string) (Genes, []*DecodeError, error) {
+	layout, err := detectLayout(hex)
+	if err != nil {
+		return Genes{}, nil, err
+	}
+	gbg, err := ParseHexLayout(hex, layout)
+	if err != nil {
+		return Genes{}, nil, err
+	}
+	return d.DecodeLenient(&gbg, layout)
+}
+
+// DecodeLenient parses the grouped binary of the given layout into a Gene object in lenient mode, as done by
+// DecodeLenient.
+func (d *Decoder) DecodeLenient(gbg *GeneBinGroup, layout *Layout) (Genes, []*DecodeError, error) {
+	if err := layout.registered(); err != nil {
+		return Genes{}, nil, err
+	}
+	return decodeLenient(layoutGroup{gbg, layout}, d.opts)
+}
+
+// DecodeBits extracts the Axie information from the 256 bit representation of the genes into a Gene object.
+func (d *Decoder) DecodeBits(bits *GeneBits256) (Genes, error) {
+	return decode(bits, d.opts)
+}
+
+// DecodeBits512 extracts the Axie information from the 512 bit representation of the genes into a Gene object.
+func (d *Decoder) DecodeBits512(bits *GeneBits512) (Genes, error) {
+	return decode(bits, d.opts)
+}
+
+// Encode converts a Gene object into its 256 hex representation, as done by Encode, using the parts of the catalog.
+func (d *Decoder) Encode(genes Genes) (string, error) {
+	gbg, err := d.EncodeBin(genes)
+	if err != nil {
+		return "", err
+	}
+	return FormatHex(&gbg)
+}
+
+// Encode512 converts a Gene object into its 512 hex representation, as done by Encode512, using the parts of the catalog.
+func (d *Decoder) Encode512(genes Genes) (string, error) {
+	gbg, err := d.EncodeBin512(genes)
+	if err != nil {
+		return "", err
+	}
+	return FormatHex512(&gbg)
+}
+
+// EncodeBin converts a Gene object into the grouped binary of the 256 bit format, using the parts of the catalog.
+func (d *Decoder) EncodeBin(genes Genes) (GeneBinGroup, error) {
+	c, err := d.Catalog()
+	if err != nil {
+		return GeneBinGroup{}, err
+	}
+	return encodeBin(genes, c)
+}
+
+// EncodeBin512 converts a Gene object into the grouped binary of the 512 bit format, using the parts of the catalog.
+func (d *Decoder) EncodeBin512(genes Genes) (GeneBinGroup, error) {
+	c, err := d.Catalog()
+	if err != nil {
+		return GeneBinGroup{}, err
+	}
+	return encodeBin512(genes, c)
+}
@@ -0,0 +1,103 @@
+package agp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Difference describes a single gene that differs between two Axies. Field names the gene, such as "class",
+// "pattern.r1" or "eyes.d". Differences of recessive genes are hidden, since they do not show on the Axie itself.
+type Difference struct {
+	Field  string `json:"field"`
+	A      string `json:"a"`
+	B      string `json:"b"`
+	Hidden bool   `json:"hidden"`
+}
+
+// GeneDiff lists the differences between the genes of two Axies.
+type GeneDiff []Difference
+
+// Diff compares the genes of two Axies. The differences are listed in the same order as the fields of Genes, with the
+// dominant gene of each pattern, color and part listed before its recessive genes.
+func Diff(a, b Genes) GeneDiff {
+	diff := GeneDiff{}
+	diff.add("class", string(a.Class), string(b.Class), false)
+	diff.add("region", string(a.Region), string(b.Region), false)
+	diff.add("tag", string(a.Tag), string(b.Tag), false)
+	diff.add("bodySkin", string(a.BodySkin), string(b.BodySkin), false)
+	diff.addGenes("pattern", [3]string{a.Pattern.D, a.Pattern.R1, a.Pattern.R2}, [3]string{b.Pattern.D, b.Pattern.R1, b.Pattern.R2})
+	diff.addGenes("color", [3]string{a.Color.D, a.Color.R1, a.Color.R2}, [3]string{b.Color.D, b.Color.R1, b.Color.R2})
+	partsA, partsB := a.parts(), b.parts()
+	for i, p := range partsA {
+		genesA := [3]PartGene{p.part.D, p.part.R1, p.part.R2}
+		genesB := [3]PartGene{partsB[i].part.D, partsB[i].part.R1, partsB[i].part.R2}
+		for j, slot := range [3]string{"d", "r1", "r2"} {
+			field := fmt.Sprintf("%s.%s", p.partType, slot)
+			diff.add(field, genesA[j].PartId, genesB[j].PartId, j > 0)
+			diff.add(field+".skin", string(genesA[j].Skin), string(genesB[j].Skin), j > 0)
+		}
+	}
+	return diff
+}
+
+// add appends a difference unless both values are the same.
+func (d *GeneDiff) add(field, a, b string, hidden bool) {
+	if a != b {
+		*d = append(*d, Difference{field, a, b, hidden})
+	}
+}
+
+// addGenes appends the differences of the dominant and recessive genes of the given field.
+func (d *GeneDiff) addGenes(field string, a, b [3]string) {
+	for i, slot := range [3]string{"d", "r1", "r2"} {
+		d.add(fmt.Sprintf("%s.%s", field, slot), a[i], b[i], i > 0)
+	}
+}
+
+// Hidden lists the differences of the recessive genes only.
+func (d GeneDiff) Hidden() GeneDiff {
+	return d.filter(true)
+}
+
+// Visible lists the differences that show on the Axies themselves.
+func (d GeneDiff) Visible() GeneDiff {
+	return d.filter(false)
+}
+
+// filter lists the differences that are either hidden or visible.
+func (d GeneDiff) filter(hidden bool) GeneDiff {
+	ret := GeneDiff{}
+	for _, diff := range d {
+		if diff.Hidden == hidden {
+			ret = append(ret, diff)
+		}
+	}
+	return ret
+}
+
+// String renders each difference on its own line, marking the hidden ones.
+func (d GeneDiff) String() string {
+	var sb strings.Builder
+	for _, diff := range d {
+		fmt.Fprintf(&sb, "%s: %s -> %s", diff.Field, orNone(diff.A), orNone(diff.B))
+		if diff.Hidden {
+			sb.WriteString(" (hidden)")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// JSON renders the differences as a JSON array.
+func (d GeneDiff) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// orNone replaces empty values with a placeholder.
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
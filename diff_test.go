@@ -0,0 +1,66 @@
+package agp
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := Genes{
+		Class:   Beast,
+		Region:  Global,
+		Pattern: PatternGene{"000001", "000010", "000011"},
+		Color:   ColorGene{"ffec51", "ffa12a", "f0c66e"},
+		Eyes:    Part{D: PartGene{PartId: "eyes-puppy"}, R1: PartGene{PartId: "eyes-zeal"}, R2: PartGene{PartId: "eyes-puppy"}},
+	}
+	b := a
+	b.Class = Plant
+	b.Pattern.R2 = "000100"
+	b.Eyes.R1 = PartGene{PartId: "eyes-chubby", Skin: Mystic}
+	tests := []struct {
+		name string
+		a    Genes
+		b    Genes
+		want GeneDiff
+	}{
+		{"SAME_GENES", a, a, GeneDiff{}},
+		{"DIFFERENT_GENES", a, b, GeneDiff{
+			{"class", "beast", "plant", false},
+			{"pattern.r2", "000011", "000100", true},
+			{"eyes.r1", "eyes-zeal", "eyes-chubby", true},
+			{"eyes.r1.skin", "", "mystic", true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Diff() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneDiff(t *testing.T) {
+	diff := GeneDiff{
+		{"class", "beast", "plant", false},
+		{"eyes.r1.skin", "", "mystic", true},
+	}
+	if got, want := diff.Visible(), diff[:1]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Visible() got = %v, want %v", got, want)
+	}
+	if got, want := diff.Hidden(), diff[1:]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Hidden() got = %v, want %v", got, want)
+	}
+	if got, want := diff.String(), "class: beast -> plant\neyes.r1.skin: none -> mystic (hidden)\n"; got != want {
+		t.Fatalf("String() got = %q, want %q", got, want)
+	}
+	data, err := diff.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	var got GeneDiff
+	if err := json.Unmarshal(data, &got); err != nil || !reflect.DeepEqual(got, diff) {
+		t.Fatalf("JSON() got = %s, want %v", data, diff)
+	}
+}
@@ -0,0 +1,419 @@
+package agp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Encode converts a Gene object into its 256 hex representation. This combines EncodeBin and FormatHex into a single function.
+func Encode(genes Genes) (string, error) {
+	gbg, err := EncodeBin(genes)
+	if err != nil {
+		return "", err
+	}
+	return FormatHex(&gbg)
+}
+
+// Encode512 converts a Gene object into its 512 hex representation. This combines EncodeBin512 and FormatHex512 into a single function.
+func Encode512(genes Genes) (string, error) {
+	gbg, err := EncodeBin512(genes)
+	if err != nil {
+		return "", err
+	}
+	return FormatHex512(&gbg)
+}
+
+// FormatHex merges the grouped binary into the 256 hex representation of the genes.
+func FormatHex(gbg *GeneBinGroup) (string, error) {
+	return FormatHexLayout(gbg, Layout256)
+}
+
+// FormatHex512 merges the grouped binary into the 512 hex representation of the genes.
+func FormatHex512(gbg *GeneBinGroup) (string, error) {
+	return FormatHexLayout(gbg, Layout512)
+}
+
+// FormatHexLayout merges the grouped binary into the hex representation of the genes using the layout.
+// Fields of the layout that are not part of GeneBinGroup are left as zeroes.
+func FormatHexLayout(gbg *GeneBinGroup, layout *Layout) (string, error) {
+	if err := layout.registered(); err != nil {
+		return "", err
+	}
+	bStr := []byte(strings.Repeat("0", layout.Size))
+	for _, f := range layout.Fields {
+		bin := gbg.field(f.Name)
+		if bin == nil {
+			continue
+		}
+		r := layout.ranges[f.Name]
+		if !isBin(*bin, r.end-r.start) {
+			return "", errors.New(fmt.Sprint("cannot encode ", f.Name, ": ", *bin))
+		}
+		copy(bStr[r.start:r.end], *bin)
+	}
+	bInt, _ := new(big.Int).SetString(string(bStr), 2)
+	return fmt.Sprintf("0x%0*x", layout.Size/4, bInt), nil
+}
+
+// isBin checks if the given string is made up of exactly size binary digits.
+func isBin(bStr string, size int) bool {
+	if len(bStr) != size {
+		return false
+	}
+	return strings.Trim(bStr, "01") == ""
+}
+
+// EncodeBin converts a Gene object into the grouped binary of the 256 bit format.
+func EncodeBin(genes Genes) (GeneBinGroup, error) {
+	c, err := getCatalog()
+	if err != nil {
+		return GeneBinGroup{}, err
+	}
+	return encodeBin(genes, c)
+}
+
+// encodeBin converts a Gene object into the grouped binary of the 256 bit format using the parts of the catalog.
+func encodeBin(genes Genes, c *Catalog) (GeneBinGroup, error) {
+	var gbg GeneBinGroup
+	var err error
+	if gbg.Class, err = getClassBin(genes.Class, 4); err != nil {
+		return GeneBinGroup{}, err
+	}
+	if gbg.Region, err = getRegionBin(genes.Region); err != nil {
+		return GeneBinGroup{}, err
+	}
+	if gbg.Tag, err = getTagBin(genes.Tag, 5); err != nil {
+		return GeneBinGroup{}, err
+	}
+	if gbg.BodySkin, err = getBodySkinBin(genes.BodySkin); err != nil {
+		return GeneBinGroup{}, err
+	}
+	gbg.Xmas = strings.Repeat("0", 12)
+	for _, p := range genes.parts() {
+		if p.part.D.Skin == Xmas1 {
+			gbg.Xmas = xmasBin.String()
+		}
+	}
+	if gbg.Pattern, err = getPatternBin(genes.Pattern, 6); err != nil {
+		return GeneBinGroup{}, err
+	}
+	if gbg.Color, err = getColorBin(c, genes.Class, genes.Color, genes.Palette, 4); err != nil {
+		return GeneBinGroup{}, err
+	}
+	enc := partEncoder{gbg: &gbg, traits: c.traits, regionSkin: GlobalSkin}
+	if err := enc.encodeParts(genes, []string{"00", "10", "11", "01"}); err != nil {
+		return GeneBinGroup{}, err
+	}
+	return gbg, nil
+}
+
+// EncodeBin512 converts a Gene object into the grouped binary of the 512 bit format.
+func EncodeBin512(genes Genes) (GeneBinGroup, error) {
+	c, err := getCatalog()
+	if err != nil {
+		return GeneBinGroup{}, err
+	}
+	return encodeBin512(genes, c)
+}
+
+// encodeBin512 converts a Gene object into the grouped binary of the 512 bit format using the parts of the catalog.
+func encodeBin512(genes Genes, c *Catalog) (GeneBinGroup, error) {
+	var gbg GeneBinGroup
+	var err error
+	if gbg.Class, err = getClassBin(genes.Class, 5); err != nil {
+		return GeneBinGroup{}, err
+	}
+	// The 512 bit format derives the region and the agamogenesis tag from the skin of the parts.
+	if genes.Region != Global && genes.Region != Japan {
+		return GeneBinGroup{}, errors.New(fmt.Sprint("cannot encode region:", genes.Region))
+	}
+	gbg.Region = strings.Repeat("0", 18)
+	tag := genes.Tag
+	if tag == Agamogenesis {
+		tag = NoTag
+	}
+	if gbg.Tag, err = getTagBin(tag, 15); err != nil {
+		return GeneBinGroup{}, err
+	}
+	if gbg.BodySkin, err = getBodySkinBin(genes.BodySkin); err != nil {
+		return GeneBinGroup{}, err
+	}
+	if gbg.Pattern, err = getPatternBin(genes.Pattern, 9); err != nil {
+		return GeneBinGroup{}, err
+	}
+	if gbg.Color, err = getColorBin(c, genes.Class, genes.Color, genes.Palette, 6); err != nil {
+		return GeneBinGroup{}, err
+	}
+	// Japanese and bionic skins alter the region and the tag, so they are only used when required.
+	skinBins := []string{"0000", "0001", "0100", "0101"}
+	if genes.Tag != NoTag {
+		skinBins = append(skinBins, "0010")
+	}
+	if genes.Region == Japan {
+		skinBins = append(skinBins, "0011")
+	}
+	enc := partEncoder{gbg: &gbg, traits: c.traits, regionSkin: GlobalSkin}
+	if genes.Region == Japan {
+		enc.regionSkin = JapanSkin
+	}
+	if err := enc.encodeParts(genes, skinBins); err != nil {
+		return GeneBinGroup{}, err
+	}
+	if genes.Region == Japan {
+		enc.requireSkin(genes, "0011", "0010")
+	}
+	if genes.Tag == Agamogenesis {
+		enc.requireSkin(genes, "0010", "0011")
+	}
+	if region, _ := getRegion(&gbg); region != genes.Region {
+		return GeneBinGroup{}, errors.New(fmt.Sprint("cannot encode region:", genes.Region))
+	}
+	if tag, _ := getTag(&gbg); tag != genes.Tag {
+		return GeneBinGroup{}, errors.New(fmt.Sprint("cannot encode tag:", genes.Tag))
+	}
+	return gbg, nil
+}
+
+// getClassBin finds the binary value with the given size that represents the class.
+func getClassBin(class Class, size int) (string, error) {
+	for bin, c := range binClassMap {
+		if c == class && len(bin) == size {
+			return bin, nil
+		}
+	}
+	return "", errors.New(fmt.Sprint("cannot encode class:", class))
+}
+
+// getRegionBin finds the binary value that represents the region.
+func getRegionBin(region Region) (string, error) {
+	for bin, r := range binRegionMap {
+		if r == region {
+			return bin, nil
+		}
+	}
+	return "", errors.New(fmt.Sprint("cannot encode region:", region))
+}
+
+// getTagBin finds the binary value with the given size that represents the tag.
+func getTagBin(tag Tag, size int) (string, error) {
+	for bin, t := range binTagMap {
+		if t == tag && len(bin) == size {
+			return bin, nil
+		}
+	}
+	return "", errors.New(fmt.Sprint("cannot encode tag:", tag))
+}
+
+// getBodySkinBin finds the binary value that represents the body skin.
+func getBodySkinBin(bodySkin BodySkin) (string, error) {
+	for bin, b := range binBodySkinMap {
+		if b == bodySkin {
+			return bin, nil
+		}
+	}
+	return "", errors.New(fmt.Sprint("cannot encode body skin:", bodySkin))
+}
+
+// getPatternBin merges the pattern genes into a single binary value, each gene having the given size.
+func getPatternBin(pattern PatternGene, size int) (string, error) {
+	for _, bin := range []string{pattern.D, pattern.R1, pattern.R2} {
+		if !isBin(bin, size) {
+			return "", errors.New(fmt.Sprint("cannot encode pattern:", bin))
+		}
+	}
+	return pattern.D + pattern.R1 + pattern.R2, nil
+}
+
+// getColorBin finds the binary values of the class colors of the catalog and merges them, each gene having the given
+// size. The bits of the palette are used as they are when they have the same size and color, which keeps unknown colors
+// intact.
+func getColorBin(c *Catalog, class Class, color ColorGene, palette PaletteGene, size int) (string, error) {
+	colorMap := c.colors[class]
+	bins := make([]string, 0, len(colorMap))
+	for bin := range colorMap {
+		if len(bin) == size {
+			bins = append(bins, bin)
+		}
+	}
+	sort.Strings(bins)
+	var bStr string
+	colors := [3]Color{palette.D, palette.R1, palette.R2}
+	for i, hex := range [3]string{color.D, color.R1, color.R2} {
+		if isBin(colors[i].Bits, size) && strings.EqualFold(colors[i].Hex, hex) {
+			bStr += colors[i].Bits
+			continue
+		}
+		bin, ok := "", false
+		for _, b := range bins {
+			if strings.EqualFold(colorMap[b].Hex, hex) {
+				bin, ok = b, true
+				break
+			}
+		}
+		// Without the bits of the palette, unknown colors may be any binary value that is not listed in the colors.json file.
+		for v := 0; !ok && (hex == "" || hex == UnknownColor) && v < 1<<uint(size); v++ {
+			b := fmt.Sprintf("%0*b", size, v)
+			if _, found := colorMap[b]; !found {
+				bin, ok = b, true
+			}
+		}
+		if !ok {
+			return "", errors.New(fmt.Sprint("cannot encode color:", hex))
+		}
+		bStr += bin
+	}
+	return bStr, nil
+}
+
+// partEncoder holds the details needed to convert the parts back into their binary values.
+// The location of each gene within a part follows the layout of the grouped binary.
+type partEncoder struct {
+	gbg    *GeneBinGroup
+	traits traitsJSON
+	// regionSkin is the skin of the genes whose own skin bits have no special skin, as done by getRecessiveGene.
+	regionSkin PartSkin
+}
+
+// partField pairs a part with the field of the grouped binary that stores it.
+type partField struct {
+	part     Part
+	partType PartType
+	bin      *string
+}
+
+// partFields lists each part of the genes along with the field of the grouped binary that stores it.
+func (enc partEncoder) partFields(genes Genes) []partField {
+	return []partField{
+		{genes.Eyes, Eyes, &enc.gbg.Eyes},
+		{genes.Ears, Ears, &enc.gbg.Ears},
+		{genes.Horn, Horn, &enc.gbg.Horn},
+		{genes.Mouth, Mouth, &enc.gbg.Mouth},
+		{genes.Back, Back, &enc.gbg.Back},
+		{genes.Tail, Tail, &enc.gbg.Tail},
+	}
+}
+
+// encodeParts converts each of the parts into binary and stores them into the grouped binary.
+func (enc partEncoder) encodeParts(genes Genes, skinBins []string) error {
+	for _, field := range enc.partFields(genes) {
+		bin, err := enc.encodePart(field.part, field.partType, skinBins)
+		if err != nil {
+			return err
+		}
+		*field.bin = bin
+	}
+	return nil
+}
+
+// requireSkin re-encodes the first part that can carry the given skin unless a part already has it.
+// Parts carrying the locked skin are left untouched.
+func (enc partEncoder) requireSkin(genes Genes, skinBin string, lockedBin string) {
+	fields := enc.partFields(genes)
+	for _, field := range fields {
+		if strings.HasPrefix(*field.bin, skinBin) {
+			return
+		}
+	}
+	for _, field := range fields {
+		if strings.HasPrefix(*field.bin, lockedBin) {
+			continue
+		}
+		if bin, err := enc.encodePart(field.part, field.partType, []string{skinBin}); err == nil {
+			*field.bin = bin
+			return
+		}
+	}
+}
+
+// encodePart converts a part into binary by trying each part skin that is decoded into the skin of the dominant gene
+// until all of its genes can be represented. Dominant genes without a skin may use any part skin.
+func (enc partEncoder) encodePart(part Part, partType PartType, skinBins []string) (string, error) {
+	layout := enc.gbg.layout()
+	pr := layout.parts[partType]
+	size := layout.ranges[string(partType)].end - layout.ranges[string(partType)].start
+	for _, skinBin := range skinBins {
+		dSkin, err := getPartSkin(enc.gbg, parseBin(skinBin))
+		if err != nil || (dSkin == Mystic) != part.Mystic || (part.D.Skin != "" && dSkin != part.D.Skin) {
+			continue
+		}
+		bStr := []byte(strings.Repeat("0", size))
+		copy(bStr[pr.skin.start:pr.skin.end], skinBin)
+		if enc.encodeGene(bStr, part.D, partType, pr.genes[0], dSkin) &&
+			enc.encodeGene(bStr, part.R1, partType, pr.genes[1], GlobalSkin) &&
+			enc.encodeGene(bStr, part.R2, partType, pr.genes[2], GlobalSkin) {
+			return string(bStr), nil
+		}
+	}
+	return "", errors.New(fmt.Sprint("cannot encode part:", part.D.PartId, part.R1.PartId, part.R2.PartId))
+}
+
+// encodeGene writes the class and part binary values of a single gene into the bits of a part. Genes with skin bits
+// of their own try each gene skin, starting from the skin of the gene, instead of using the given skin.
+func (enc partEncoder) encodeGene(bStr []byte, partGene PartGene, partType PartType, gr geneRange, skin PartSkin) bool {
+	skinBins := []string{""}
+	if gr.skin.end > gr.skin.start {
+		skinBins = getGeneSkinBins(partGene.Skin)
+	}
+	for _, skinBin := range skinBins {
+		if skinBin != "" {
+			if skin = binGeneSkinMap[skinBin]; skin == GlobalSkin {
+				skin = enc.regionSkin
+			}
+		}
+		classBin, bin, ok := enc.findPartBin(partGene, partType, skin, gr.class.end-gr.class.start)
+		if !ok {
+			continue
+		}
+		copy(bStr[gr.class.start:gr.class.end], classBin)
+		copy(bStr[gr.skin.start:gr.skin.end], skinBin)
+		copy(bStr[gr.part.start:gr.part.end], bin)
+		return true
+	}
+	return false
+}
+
+// getGeneSkinBins lists the skin bits of a single gene, starting from the ones representing the given skin.
+func getGeneSkinBins(skin PartSkin) []string {
+	skinBins := make([]string, 0, len(binGeneSkinMap))
+	for skinBin := range binGeneSkinMap {
+		skinBins = append(skinBins, skinBin)
+	}
+	sort.Strings(skinBins)
+	sort.SliceStable(skinBins, func(i, j int) bool {
+		return binGeneSkinMap[skinBins[i]] == skin && binGeneSkinMap[skinBins[j]] != skin
+	})
+	return skinBins
+}
+
+// findPartBin looks for the class and part binary values that are decoded into the given part gene.
+func (enc partEncoder) findPartBin(partGene PartGene, partType PartType, skin PartSkin, classSize int) (string, string, bool) {
+	classes := make([]string, 0, len(enc.traits))
+	for class := range enc.traits {
+		classes = append(classes, string(class))
+	}
+	sort.Strings(classes)
+	// Prefer the class of the part itself in case the same part is listed under multiple classes.
+	sort.SliceStable(classes, func(i, j int) bool { return Class(classes[i]) == partGene.Class && Class(classes[j]) != partGene.Class })
+	for _, class := range classes {
+		parts := enc.traits[Class(class)][partType]
+		bins := make([]string, 0, len(parts))
+		for bin := range parts {
+			bins = append(bins, bin)
+		}
+		sort.Strings(bins)
+		for _, bin := range bins {
+			if getPartId(partType, resolvePartName(parts[bin], skin)) != partGene.PartId {
+				continue
+			}
+			classBin, err := getClassBin(Class(class), classSize)
+			if err != nil {
+				continue
+			}
+			return classBin, bin, true
+		}
+	}
+	return "", "", false
+}
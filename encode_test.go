@@ -0,0 +1,137 @@
+package agp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+		want string
+	}{
+		{"DEFAULT", "0x11c642400a028ca14a428c20cc011080c61180a0820180604233082", "0x00000000011c642400a028ca14a428c20cc011080c61180a0820180604233082"},
+		{"JAPAN_MYSTIC", "0x00080000011c6424c0a028ca14a428c20cc011080c61180a0820180604233082", "0x00080000011c6424c0a028ca14a428c20cc011080c61180a0820180604233082"},
+		{"PURE", "0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2", "0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2"},
+		{"XMAS", "0x00000155411c642400a028ca14a428c20cc011080c61180a0820180604233082", "0x00000155411c642400a028ca14a428c20cc011080c61180a0820180604233082"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genes, err := ParseHexDecode(tt.hex)
+			if err != nil {
+				t.Fatalf("Encode() error occured while decoding hex = %v", err)
+				return
+			}
+			got, err := Encode(genes)
+			if err != nil {
+				t.Fatalf("Encode() unexpected error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("Encode() got = %v, want %v", got, tt.want)
+			}
+			decoded, err := ParseHexDecode(got)
+			if err != nil {
+				t.Fatalf("Encode() error occured while decoding encoded hex = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(decoded, genes) {
+				t.Fatalf("Encode() decoded = %v,\nwant %v", decoded, genes)
+			}
+		})
+	}
+}
+
+func TestEncode512(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+	}{
+		{"DEFAULT", "0x00000000000000000040e06102100000000000002801430a00000014288143020000000c300084080000000c1820c00a000000080800c0060000000408618202"},
+		{"JAPAN_MYSTIC_AGAMOGENESIS", "0x180000000000000001008040020c00000000008c106083040000000c086043020000000c2861830a0000000c1860c30a0000018c3061830c0000010c08604302"},
+		{"JAPAN_RECESSIVE", "0x180000000000000001008040020c00000000008c106083040000000c086043020000000c2861830a0000000c1860c30a0000018c3060830c0000010c08604302"},
+		{"RECESSIVE_SKINS", "0x00000000000000000040e06102100000000000002818434a00000014288143020000000c300084080000000c1820c00a000000080800c0060000000408618202"},
+		{"UNKNOWN_COLOR", "0x00000000000000000040e06882100000000000002801430a00000014288143020000000c300084080000000c1820c00a000000080800c0060000000408618202"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genes, err := ParseHexDecode512(tt.hex)
+			if err != nil {
+				t.Fatalf("Encode512() error occured while decoding hex = %v", err)
+				return
+			}
+			got, err := Encode512(genes)
+			if err != nil {
+				t.Fatalf("Encode512() unexpected error = %v", err)
+				return
+			}
+			decoded, err := ParseHexDecode512(got)
+			if err != nil {
+				t.Fatalf("Encode512() error occured while decoding encoded hex = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(decoded, genes) {
+				t.Fatalf("Encode512() decoded = %v,\nwant %v", decoded, genes)
+			}
+		})
+	}
+}
+
+func TestEncodeBin(t *testing.T) {
+	genes, _ := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	tests := []struct {
+		name    string
+		genes   func(Genes) Genes
+		wantErr bool
+	}{
+		{"VALID_GENES", func(g Genes) Genes { return g }, false},
+		{"INVALID_CLASS", func(g Genes) Genes { g.Class = "dragon"; return g }, true},
+		{"INVALID_PATTERN", func(g Genes) Genes { g.Pattern.D = "000000001"; return g }, true},
+		{"INVALID_COLOR", func(g Genes) Genes { g.Color.R1 = "000000"; return g }, true},
+		{"UNKNOWN_COLOR", func(g Genes) Genes { g.Color.R1 = UnknownColor; return g }, false},
+		{"INVALID_PART", func(g Genes) Genes { g.Eyes.D = PartGene{"eyes-unknown", Beast, "", Eyes, "Unknown", "", ""}; return g }, true},
+		{"INVALID_MYSTIC", func(g Genes) Genes { g.Tail.Mystic = true; return g }, true},
+		{"INVALID_SKIN", func(g Genes) Genes { g.Eyes.D.Skin = JapanSkin; return g }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EncodeBin(tt.genes(genes))
+			if err == nil && tt.wantErr {
+				t.Fatalf("EncodeBin() expected an error")
+				return
+			}
+			if err != nil && !tt.wantErr {
+				t.Fatalf("EncodeBin() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestDecoderEncode(t *testing.T) {
+	custom, err := ReadCatalog(strings.NewReader(`{
+		"traits": {"beast": {"eyes": {"001100": {"global": "New Sight"}}}},
+		"parts": {"eyes-new-sight": {"class": "beast", "name": "New Sight", "partId": "eyes-new-sight", "specialGenes": "", "type": "eyes"}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults, _ := DefaultCatalog()
+	merged, _ := defaults.Merge(custom)
+	decoder := NewDecoder(merged)
+	gbg, _ := ParseHex("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	gbg.Eyes = gbg.Eyes[:6] + "001100" + gbg.Eyes[12:]
+	hex, _ := FormatHex(&gbg)
+	genes, err := decoder.Decode(&gbg)
+	if err != nil {
+		t.Fatalf("Decode() unexpected error = %v", err)
+	}
+	if got, err := decoder.Encode(genes); err != nil || got != hex {
+		t.Fatalf("Decoder.Encode() got = %v, %v, want %v", got, err, hex)
+	}
+	if _, err := Encode(genes); err == nil {
+		t.Fatalf("Encode() expected an error for a part missing from the embedded catalog")
+	}
+
+}
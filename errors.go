@@ -0,0 +1,110 @@
+package agp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidHex is reported when the hex representation of the genes is malformed or too long for its format.
+var ErrInvalidHex = errors.New("invalid hex")
+
+// ErrUnknownCard is reported when a part has no card in the cards.json file.
+var ErrUnknownCard = errors.New("unknown card")
+
+// ErrInconsistentChildren is reported by InferRecessives when a child could not have been bred from its parents.
+var ErrInconsistentChildren = errors.New("inconsistent children")
+
+// ErrInvalidGenes is reported by Validate, and by the decode functions in strict mode, when the genes break any of
+// the rules checked by Validate. Use errors.As with a *ValidationError to list the violations.
+var ErrInvalidGenes = errors.New("invalid genes")
+
+// ErrLenientDecoding is reported by the decode functions that only return the genes when WithLenientDecoding is given,
+// since they would drop the warnings. Use DecodeLenient or ParseHexDecodeLenient instead.
+var ErrLenientDecoding = errors.New("lenient decoding requires a function returning the warnings")
+
+// ErrInvalidLayout is reported when a Layout cannot be registered or is used without being registered.
+var ErrInvalidLayout = errors.New("invalid layout")
+
+// Errors reported when a group of bits does not match any known value. Use errors.Is to check for these, and
+// errors.As with a *DecodeError to find out which bits caused them.
+var (
+	ErrUnknownClass    = errors.New("unknown class")
+	ErrUnknownRegion   = errors.New("unknown region")
+	ErrUnknownTag      = errors.New("unknown tag")
+	ErrUnknownBodySkin = errors.New("unknown body skin")
+	ErrUnknownPart     = errors.New("unknown part")
+	ErrUnknownSkin     = errors.New("unknown skin")
+	ErrUnknownPattern  = errors.New("unknown pattern")
+)
+
+// DecodeError describes a group of bits that could not be decoded.
+type DecodeError struct {
+	// Err is the kind of failure, such as ErrUnknownClass or ErrUnknownPart.
+	Err error
+	// Field is the name of the group of bits, such as "class" or "eyes.r1.class".
+	Field string
+	// Start and End mark the range of the bits within the binary representation of the genes.
+	Start int
+	End   int
+	// Bits holds the raw bits that could not be decoded.
+	Bits string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%v: %s bits [%d:%d] = %s", e.Err, e.Field, e.Start, e.End, e.Bits)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError creates a DecodeError for the given field of the grouped binary.
+func newDecodeError(err error, r binReader, field string, bits Bin) *DecodeError {
+	fr := r.layout().ranges[field]
+	return &DecodeError{Err: err, Field: field, Start: fr.start, End: fr.end, Bits: bits.String()}
+}
+
+// newPartError creates a DecodeError for a group of bits within a part. The range is relative to the start of the part.
+func newPartError(err error, r binReader, partType PartType, field string, fr binRange, bits Bin) *DecodeError {
+	return newSubError(err, r, string(partType), field, fr, bits)
+}
+
+// newSubError creates a DecodeError for a group of bits within the given parent field. The range is relative to the
+// start of the parent.
+func newSubError(err error, r binReader, parent string, field string, fr binRange, bits Bin) *DecodeError {
+	offset := r.layout().ranges[parent].start
+	return &DecodeError{Err: err, Field: fmt.Sprintf("%s.%s", parent, field), Start: offset + fr.start, End: offset + fr.end, Bits: bits.String()}
+}
+
+// asPartError moves a DecodeError into a group of bits within a part. Errors of other types are returned as is.
+func asPartError(err error, r binReader, partType PartType, field string, fr binRange, bits Bin) error {
+	return asSubError(err, r, string(partType), field, fr, bits)
+}
+
+// asSubError moves a DecodeError into a group of bits within the given parent field. Errors of other types are
+// returned as is.
+func asSubError(err error, r binReader, parent string, field string, fr binRange, bits Bin) error {
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return newSubError(decodeErr.Err, r, parent, field, fr, bits)
+	}
+	return err
+}
+
+// ValidationError lists every rule broken by the genes checked by Validate.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	reasons := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		reasons[i] = v.String()
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidGenes, strings.Join(reasons, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidGenes
+}
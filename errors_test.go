@@ -0,0 +1,43 @@
+package agp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeError(t *testing.T) {
+	gbg, _ := ParseHex("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	gbg512, _ := ParseHex512("0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302")
+	tests := []struct {
+		name    string
+		decode  func(*GeneBinGroup, ...DecodeOption) (Genes, error)
+		gbg     GeneBinGroup
+		modify  func(*GeneBinGroup)
+		wantErr error
+		want    DecodeError
+	}{
+		{"UNKNOWN_CLASS", Decode, gbg, func(g *GeneBinGroup) { g.Class = "1111" }, ErrUnknownClass, DecodeError{nil, "class", 0, 4, "1111"}},
+		{"UNKNOWN_TAG", Decode, gbg, func(g *GeneBinGroup) { g.Tag = "11111" }, ErrUnknownTag, DecodeError{nil, "tag", 13, 18, "11111"}},
+		{"UNKNOWN_BODY_SKIN", Decode, gbg, func(g *GeneBinGroup) { g.BodySkin = "0011" }, ErrUnknownBodySkin, DecodeError{nil, "bodySkin", 18, 22, "0011"}},
+		{"UNKNOWN_PART_CLASS", Decode, gbg, func(g *GeneBinGroup) { g.Ears = g.Ears[:12] + "1111" + g.Ears[16:] }, ErrUnknownClass, DecodeError{nil, "ears.r1.class", 140, 144, "1111"}},
+		{"UNKNOWN_PART", Decode, gbg, func(g *GeneBinGroup) { g.Eyes = g.Eyes[:6] + "111111" + g.Eyes[12:] }, ErrUnknownPart, DecodeError{nil, "eyes.d", 70, 76, "111111"}},
+		{"UNKNOWN_SKIN", Decode512, gbg512, func(g *GeneBinGroup) { g.Tail = "1111" + g.Tail[4:] }, ErrUnknownSkin, DecodeError{nil, "tail.skin", 469, 473, "1111"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gbg := tt.gbg
+			tt.modify(&gbg)
+			_, err := tt.decode(&gbg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeError got = %v, want %v", err, tt.wantErr)
+			}
+			var got *DecodeError
+			if !errors.As(err, &got) {
+				t.Fatalf("DecodeError expected a *DecodeError, got %T", err)
+			}
+			if got.Field != tt.want.Field || got.Start != tt.want.Start || got.End != tt.want.End || got.Bits != tt.want.Bits {
+				t.Fatalf("DecodeError got = %v, want %s bits [%d:%d] = %s", got, tt.want.Field, tt.want.Start, tt.want.End, tt.want.Bits)
+			}
+		})
+	}
+}
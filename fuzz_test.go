@@ -0,0 +1,64 @@
+//go:build go1.18
+// +build go1.18
+
+package agp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func FuzzParseHex(f *testing.F) {
+	f.Add("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	f.Add("0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2")
+	f.Add("")
+	f.Add("0x")
+	f.Fuzz(func(t *testing.T, hex string) {
+		gbg, err := ParseHex(hex)
+		if err != nil {
+			return
+		}
+		_, _ = Decode(&gbg)
+	})
+}
+
+func FuzzParseHex512(f *testing.F) {
+	f.Add("0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302")
+	f.Add("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	f.Add("")
+	f.Add("0x")
+	f.Fuzz(func(t *testing.T, hex string) {
+		gbg, err := ParseHex512(hex)
+		if err != nil {
+			return
+		}
+		_, _ = Decode512(&gbg)
+	})
+}
+
+func FuzzDecodeBits(f *testing.F) {
+	f.Add("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082", false)
+	f.Add("0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302", true)
+	f.Fuzz(func(t *testing.T, hex string, is512 bool) {
+		var got, want Genes
+		var gotErr, wantErr error
+		if is512 {
+			bits, err := ParseBits512(hex)
+			if err != nil {
+				return
+			}
+			got, gotErr = DecodeBits512(&bits)
+			want, wantErr = ParseHexDecode512(hex)
+		} else {
+			bits, err := ParseBits(hex)
+			if err != nil {
+				return
+			}
+			got, gotErr = DecodeBits(&bits)
+			want, wantErr = ParseHexDecode(hex)
+		}
+		if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(gotErr, wantErr) {
+			t.Fatalf("DecodeBits() got = %v, %v,\nwant %v, %v", got, gotErr, want, wantErr)
+		}
+	})
+}
@@ -17,6 +17,39 @@ type GeneBinGroup struct {
 	Tail     string
 }
 
+// field points to the group of bits with the given name, or nil when the grouped binary has no such group.
+func (gbg *GeneBinGroup) field(name string) *string {
+	switch name {
+	case "class":
+		return &gbg.Class
+	case "region":
+		return &gbg.Region
+	case "tag":
+		return &gbg.Tag
+	case "bodySkin":
+		return &gbg.BodySkin
+	case "xmas":
+		return &gbg.Xmas
+	case "pattern":
+		return &gbg.Pattern
+	case "color":
+		return &gbg.Color
+	case "eyes":
+		return &gbg.Eyes
+	case "ears":
+		return &gbg.Ears
+	case "horn":
+		return &gbg.Horn
+	case "mouth":
+		return &gbg.Mouth
+	case "back":
+		return &gbg.Back
+	case "tail":
+		return &gbg.Tail
+	}
+	return nil
+}
+
 // Genes contains the overall data about the Axie's gene.
 type Genes struct {
 	Class       Class       `json:"class,omitempty"`
@@ -24,7 +57,9 @@ type Genes struct {
 	Tag         Tag         `json:"tag,omitempty"`
 	BodySkin    BodySkin    `json:"bodySkin,omitempty"`
 	Pattern     PatternGene `json:"pattern,omitempty"`
+	Body        BodyGene    `json:"body,omitempty"`
 	Color       ColorGene   `json:"color,omitempty"`
+	Palette     PaletteGene `json:"palette,omitempty"`
 	Eyes        Part        `json:"eyes,omitempty"`
 	Mouth       Part        `json:"mouth,omitempty"`
 	Ears        Part        `json:"ears,omitempty"`
@@ -34,6 +69,17 @@ type Genes struct {
 	GeneQuality float64     `json:"geneQuality,omitempty"`
 }
 
+// typedPart is a part of an Axie along with its type.
+type typedPart struct {
+	partType PartType
+	part     Part
+}
+
+// parts lists each part of the Axie along with its type.
+func (g Genes) parts() [6]typedPart {
+	return [6]typedPart{{Eyes, g.Eyes}, {Ears, g.Ears}, {Horn, g.Horn}, {Mouth, g.Mouth}, {Back, g.Back}, {Tail, g.Tail}}
+}
+
 // Part stores the dominant and recessive genes of an Axie's part.
 type Part struct {
 	D      PartGene `json:"d1,omitempty"`
@@ -42,13 +88,16 @@ type Part struct {
 	Mystic bool     `json:"mystic,omitempty"`
 }
 
-// PartGene holds the data for a single gene of an Axie's part.
+// PartGene holds the data for a single gene of an Axie's part. Genes that could not be decoded in lenient mode are
+// placeholders, which have no PartId and hold the raw bits of the gene in Bits.
 type PartGene struct {
 	PartId       string   `json:"partId,omitempty"`
 	Class        Class    `json:"class,omitempty"`
 	SpecialGenes string   `json:"specialGenes,omitempty"`
 	Type         PartType `json:"type,omitempty"`
 	Name         string   `json:"name,omitempty"`
+	Skin         PartSkin `json:"skin,omitempty"`
+	Bits         string   `json:"bits,omitempty"`
 }
 
 // PatternGene stores the dominant and recessive genes of an Axie's skin pattern.
@@ -58,6 +107,19 @@ type PatternGene struct {
 	R2 string `json:"r2,omitempty"`
 }
 
+// BodyGene stores the dominant and recessive body patterns of an Axie, as decoded from its pattern genes.
+type BodyGene struct {
+	D  BodyPattern `json:"d,omitempty"`
+	R1 BodyPattern `json:"r1,omitempty"`
+	R2 BodyPattern `json:"r2,omitempty"`
+}
+
+// BodyPattern is the shape of the body and the index of the pattern drawn on it.
+type BodyPattern struct {
+	Shape   BodyShape `json:"shape,omitempty"`
+	Pattern int       `json:"pattern"`
+}
+
 // ColorGene stores the dominant and recessive genes of an Axie's color.
 type ColorGene struct {
 	D  string `json:"d,omitempty"`
@@ -65,6 +127,38 @@ type ColorGene struct {
 	R2 string `json:"r2,omitempty"`
 }
 
+// PaletteGene stores the details of the dominant and recessive color genes of an Axie.
+type PaletteGene struct {
+	D  Color `json:"d,omitempty"`
+	R1 Color `json:"r1,omitempty"`
+	R2 Color `json:"r2,omitempty"`
+}
+
+// colorGene lists the hex of each color of the palette.
+func (pg PaletteGene) colorGene() ColorGene {
+	return ColorGene{pg.D.Hex, pg.R1.Hex, pg.R2.Hex}
+}
+
+// Color holds the details of a single color gene. Palette is the class that the color belongs to, or "shared" for
+// colors that every class has. Colors missing from the colors.json file have UnknownColor as their hex and name.
+type Color struct {
+	Bits    string `json:"bits,omitempty"`
+	Hex     string `json:"hex,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Palette string `json:"palette,omitempty"`
+}
+
+// UnknownColor marks a color gene whose bits are not listed in the colors.json file.
+const UnknownColor = "unknown"
+
+// GeneFormat represents the bit size of the hex representation of the genes. This can either be 256 or 512 bits.
+type GeneFormat int
+
+const (
+	Format256 GeneFormat = 256
+	Format512 GeneFormat = 512
+)
+
 // PartType represents each of an Axies body parts including: Eeyes, Ears, Mouth, Horn, Back, Tail.
 type PartType string
 
@@ -120,6 +214,22 @@ const (
 	Frosty               = "frosty"
 )
 
+// BodyShape represents the shape of an Axie's body.
+type BodyShape string
+
+const (
+	NormalBody BodyShape = "normal"
+	CurlyBody  BodyShape = "curly"
+	SumoBody   BodyShape = "sumo"
+	BigYakBody BodyShape = "big-yak"
+	WetDogBody BodyShape = "wet-dog"
+	FuzzyBody  BodyShape = "fuzzy"
+	SpikyBody  BodyShape = "spiky"
+)
+
+// UnknownShape marks a body pattern whose shape bits are not listed in the patterns.json file.
+const UnknownShape BodyShape = "unknown"
+
 // PartSkin represents the special skin of an Axie's part. This can be Global, Japan, Xmas, Mystic, Bionic (Agamogenesis)
 type PartSkin string
 
@@ -1,5 +1,3 @@
 module github.com/shanemaglangit/agp
 
 go 1.16
-
-require github.com/ethereum/go-ethereum v1.10.8
@@ -0,0 +1,155 @@
+package agp
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Child is an offspring of the parent whose genes are inferred by InferRecessives, along with its other parent.
+// Genes whose PartId is empty are unknown. Unknown genes of the other parent may be any part.
+type Child struct {
+	Genes       Genes `json:"genes"`
+	OtherParent Genes `json:"otherParent"`
+}
+
+// PartPosterior lists the probability of each part being the recessive genes of a parent's part, from the most to the
+// least likely.
+type PartPosterior struct {
+	R1 []GenePosterior `json:"r1"`
+	R2 []GenePosterior `json:"r2"`
+}
+
+// GenePosterior holds the probability of a recessive gene being the given part. An empty PartId stands for any part
+// that none of the children have.
+type GenePosterior struct {
+	PartId      string  `json:"partId"`
+	Probability float64 `json:"probability"`
+}
+
+// InferRecessives computes the probability of each part being the recessive genes of the parent, given the genes of its
+// children. Recessive genes of the parent with a PartId are known and kept as they are, while unknown ones start with
+// the same chance of being any part found on the children, or a part that none of them have. Each gene of a child is
+// inherited as computed by Breed. The inference fails with ErrInconsistentChildren when a child could not have been
+// bred from its parents whatever the recessive genes are.
+func InferRecessives(parent Genes, children []Child) (map[PartType]PartPosterior, error) {
+	posteriors := map[PartType]PartPosterior{}
+	for i, p := range parent.parts() {
+		posterior, err := inferPartRecessives(i, p.part, children)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, p.partType)
+		}
+		posteriors[p.partType] = posterior
+	}
+	return posteriors, nil
+}
+
+// recessiveHypothesis is a possible pair of recessive genes of the parent's part, along with its probability.
+type recessiveHypothesis struct {
+	r1, r2      string
+	probability float64
+}
+
+// inferPartRecessives computes the probability of each pair of recessive genes of the part, and sums them up by slot.
+func inferPartRecessives(index int, part Part, children []Child) (PartPosterior, error) {
+	// Unknown recessive genes may be any part found on the children, or a part that none of them have.
+	candidates := []string{""}
+	seen := map[string]bool{}
+	for _, child := range children {
+		childPart := child.Genes.parts()[index].part
+		for _, gene := range [3]PartGene{childPart.D, childPart.R1, childPart.R2} {
+			if gene.PartId != "" && !seen[gene.PartId] {
+				seen[gene.PartId] = true
+				candidates = append(candidates, gene.PartId)
+			}
+		}
+	}
+	r1Candidates, r2Candidates := candidates, candidates
+	if part.R1.PartId != "" {
+		r1Candidates = []string{part.R1.PartId}
+	}
+	if part.R2.PartId != "" {
+		r2Candidates = []string{part.R2.PartId}
+	}
+	var hypotheses []recessiveHypothesis
+	for _, r1 := range r1Candidates {
+		for _, r2 := range r2Candidates {
+			hypotheses = append(hypotheses, recessiveHypothesis{r1, r2, 1})
+		}
+	}
+	for _, child := range children {
+		childPart := child.Genes.parts()[index].part
+		otherPart := child.OtherParent.parts()[index].part
+		total := 0.0
+		for h := range hypotheses {
+			genes := [3]inheritedGene{
+				{part.D.PartId, dInheritance, part.D.PartId == ""},
+				{hypotheses[h].r1, r1Inheritance, false},
+				{hypotheses[h].r2, r2Inheritance, false},
+			}
+			for _, gene := range [3]PartGene{childPart.D, childPart.R1, childPart.R2} {
+				if gene.PartId != "" {
+					hypotheses[h].probability *= getInheritedChance(genes, otherPart, gene.PartId)
+				}
+			}
+			total += hypotheses[h].probability
+		}
+		if total == 0 {
+			return PartPosterior{}, ErrInconsistentChildren
+		}
+		// Normalizing after each child keeps the probabilities from vanishing when there are many children.
+		for h := range hypotheses {
+			hypotheses[h].probability /= total
+		}
+	}
+	if len(children) == 0 {
+		for h := range hypotheses {
+			hypotheses[h].probability /= float64(len(hypotheses))
+		}
+	}
+	r1, r2 := map[string]float64{}, map[string]float64{}
+	for _, h := range hypotheses {
+		r1[h.r1] += h.probability
+		r2[h.r2] += h.probability
+	}
+	return PartPosterior{R1: sortPosterior(r1), R2: sortPosterior(r2)}, nil
+}
+
+// inheritedGene is a gene of a parent's part along with its chance of being inherited. Wildcard genes are unknown, and
+// may be any part.
+type inheritedGene struct {
+	partId   string
+	chance   float64
+	wildcard bool
+}
+
+// getInheritedChance computes the chance of a gene of the child being the given part, given the genes of the parent
+// and the part of the other parent.
+func getInheritedChance(genes [3]inheritedGene, otherPart Part, partId string) float64 {
+	chance := 0.0
+	for _, gene := range genes {
+		if gene.wildcard || gene.partId == partId {
+			chance += gene.chance
+		}
+	}
+	for _, gene := range []GeneOdds{{otherPart.D, dInheritance}, {otherPart.R1, r1Inheritance}, {otherPart.R2, r2Inheritance}} {
+		if gene.Gene.PartId == "" || gene.Gene.PartId == partId {
+			chance += gene.Probability
+		}
+	}
+	return chance
+}
+
+// sortPosterior lists the probability of each part from the most to the least likely.
+func sortPosterior(probabilities map[string]float64) []GenePosterior {
+	posterior := make([]GenePosterior, 0, len(probabilities))
+	for partId, probability := range probabilities {
+		posterior = append(posterior, GenePosterior{partId, probability})
+	}
+	sort.Slice(posterior, func(i, j int) bool {
+		if posterior[i].Probability != posterior[j].Probability {
+			return posterior[i].Probability > posterior[j].Probability
+		}
+		return posterior[i].PartId < posterior[j].PartId
+	})
+	return posterior
+}
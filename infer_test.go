@@ -0,0 +1,61 @@
+package agp
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestInferRecessives(t *testing.T) {
+	parent := Genes{Mouth: partOf("mouth-goda", "", "")}
+	other := Genes{Mouth: partOf("mouth-kotaro", "mouth-kotaro", "mouth-kotaro")}
+	// The nut cracker can only come from the recessive genes of the parent.
+	child := Child{Genes{Mouth: partOf("mouth-nut-cracker", "mouth-kotaro", "mouth-kotaro")}, other}
+	known := parent
+	known.Mouth.R1 = PartGene{PartId: "mouth-nut-cracker"}
+	complete := known
+	complete.Mouth.R2 = PartGene{PartId: "mouth-goda"}
+	tests := []struct {
+		name     string
+		parent   Genes
+		children []Child
+		wantR1   []string
+		wantR2   []string
+		wantErr  error
+	}{
+		{"UNKNOWN_RECESSIVES", parent, []Child{child, child}, []string{"mouth-nut-cracker", "mouth-kotaro", ""}, []string{"mouth-nut-cracker", "mouth-kotaro", ""}, nil},
+		{"KNOWN_RECESSIVE", known, []Child{child}, []string{"mouth-nut-cracker"}, []string{"mouth-nut-cracker", "mouth-kotaro", ""}, nil},
+		{"NO_CHILDREN", parent, nil, []string{""}, []string{""}, nil},
+		{"INCONSISTENT_CHILDREN", complete, []Child{{Genes{Mouth: partOf("mouth-zigzag", "", "")}, other}}, nil, nil, ErrInconsistentChildren},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posteriors, err := InferRecessives(tt.parent, tt.children)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InferRecessives() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, slot := range []struct {
+				name      string
+				posterior []GenePosterior
+				want      []string
+			}{{"r1", posteriors[Mouth].R1, tt.wantR1}, {"r2", posteriors[Mouth].R2, tt.wantR2}} {
+				var got []string
+				total := 0.0
+				for _, gp := range slot.posterior {
+					got = append(got, gp.PartId)
+					total += gp.Probability
+				}
+				if !reflect.DeepEqual(got, slot.want) {
+					t.Fatalf("InferRecessives() %s got = %v, want %v", slot.name, slot.posterior, slot.want)
+				}
+				if math.Abs(total-1) > 1e-9 {
+					t.Fatalf("InferRecessives() %s probabilities add up to %v, want 1", slot.name, total)
+				}
+			}
+		})
+	}
+}
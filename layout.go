@@ -0,0 +1,330 @@
+package agp
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Layout describes where each group of bits is located within the binary representation of the genes. Layouts are
+// registered by name with RegisterLayout, and used by ParseHexLayout, DecodeLayout and FormatHexLayout.
+// A layout must not be modified once it is registered.
+type Layout struct {
+	// Name identifies the layout, such as "256" or "512".
+	Name string
+	// Size is the number of bits of the genes. It must be a multiple of 64.
+	Size int
+	// Fields lists the groups of bits, named after the fields of GeneBinGroup such as "class" or "eyes". Each part has
+	// a "skin" sub-field along with "d", "r1" and "r2" sub-fields, each holding the "class" and "part" of a gene.
+	// The skin of the part applies to the dominant gene. Recessive genes are read with the global skin unless they
+	// have a "skin" sub-field of their own.
+	Fields []Field
+
+	ranges map[string]binRange
+	parts  map[PartType]partRange
+}
+
+// Field is a named group of bits within a Layout. The offset of a sub-field is relative to the start of its parent.
+type Field struct {
+	Name   string  `json:"name"`
+	Offset int     `json:"offset"`
+	Width  int     `json:"width"`
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// binRange marks where a group of bits is located within the binary representation of the genes.
+type binRange struct {
+	start int
+	end   int
+}
+
+// geneRange marks where the class, skin and part bits of a single gene are located within the bits of a part.
+// The skin range is empty when the gene has no skin bits of its own.
+type geneRange struct {
+	slot  string
+	class binRange
+	skin  binRange
+	part  binRange
+}
+
+// partRange marks where the skin and the genes are located within the bits of a part.
+type partRange struct {
+	skin  binRange
+	genes [3]geneRange
+}
+
+// Layout256 is the layout of the 256 bit format.
+var Layout256 = mustRegisterLayout(&Layout{
+	Name: "256",
+	Size: 256,
+	Fields: []Field{
+		{Name: "class", Offset: 0, Width: 4},
+		{Name: "region", Offset: 8, Width: 5},
+		{Name: "tag", Offset: 13, Width: 5},
+		{Name: "bodySkin", Offset: 18, Width: 4},
+		{Name: "xmas", Offset: 22, Width: 12},
+		{Name: "pattern", Offset: 34, Width: 18},
+		{Name: "color", Offset: 52, Width: 12},
+		partField256(Eyes, 64),
+		partField256(Mouth, 96),
+		partField256(Ears, 128),
+		partField256(Horn, 160),
+		partField256(Back, 192),
+		partField256(Tail, 224),
+	},
+})
+
+// Layout512 is the layout of the 512 bit format.
+var Layout512 = mustRegisterLayout(&Layout{
+	Name: "512",
+	Size: 512,
+	Fields: []Field{
+		{Name: "class", Offset: 0, Width: 5},
+		{Name: "region", Offset: 22, Width: 18},
+		{Name: "tag", Offset: 40, Width: 15},
+		{Name: "bodySkin", Offset: 61, Width: 4},
+		{Name: "pattern", Offset: 65, Width: 27},
+		{Name: "color", Offset: 92, Width: 18},
+		partField512(Eyes, 149),
+		partField512(Mouth, 213),
+		partField512(Ears, 277),
+		partField512(Horn, 341),
+		partField512(Back, 405),
+		partField512(Tail, 469),
+	},
+})
+
+// partField256 describes the bits of a part of the 256 bit format starting at the given offset.
+func partField256(partType PartType, offset int) Field {
+	gene := func(slot string, offset int) Field {
+		return Field{Name: slot, Offset: offset, Width: 10, Fields: []Field{
+			{Name: "class", Offset: 0, Width: 4},
+			{Name: "part", Offset: 4, Width: 6},
+		}}
+	}
+	return Field{Name: string(partType), Offset: offset, Width: 32, Fields: []Field{
+		{Name: "skin", Offset: 0, Width: 2},
+		gene("d", 2),
+		gene("r1", 12),
+		gene("r2", 22),
+	}}
+}
+
+// partField512 describes the bits of a part of the 512 bit format starting at the given offset.
+// The bits between the class and the part of the dominant gene are unused, since the skin of the part applies to it.
+func partField512(partType PartType, offset int) Field {
+	gene := func(slot string, offset int, skin bool) Field {
+		field := Field{Name: slot, Offset: offset, Width: 13, Fields: []Field{
+			{Name: "class", Offset: 0, Width: 5},
+			{Name: "part", Offset: 7, Width: 6},
+		}}
+		if skin {
+			field.Fields = append(field.Fields, Field{Name: "skin", Offset: 5, Width: 2})
+		}
+		return field
+	}
+	return Field{Name: string(partType), Offset: offset, Width: 43, Fields: []Field{
+		{Name: "skin", Offset: 0, Width: 4},
+		gene("d", 4, false),
+		gene("r1", 17, true),
+		gene("r2", 30, true),
+	}}
+}
+
+// layoutRegistry holds the registered layouts by name.
+var layoutRegistry = struct {
+	sync.RWMutex
+	layouts map[string]*Layout
+}{layouts: map[string]*Layout{}}
+
+// RegisterLayout checks the fields of the layout and makes it available through LookupLayout.
+// Layouts cannot be registered twice under the same name.
+func RegisterLayout(layout *Layout) error {
+	if err := layout.compile(); err != nil {
+		return err
+	}
+	layoutRegistry.Lock()
+	defer layoutRegistry.Unlock()
+	if _, ok := layoutRegistry.layouts[layout.Name]; ok {
+		return fmt.Errorf("%w: %s is already registered", ErrInvalidLayout, layout.Name)
+	}
+	layoutRegistry.layouts[layout.Name] = layout
+	return nil
+}
+
+// mustRegisterLayout registers one of the built-in layouts, which are known to be valid.
+func mustRegisterLayout(layout *Layout) *Layout {
+	if err := RegisterLayout(layout); err != nil {
+		panic(err)
+	}
+	return layout
+}
+
+// LookupLayout finds the registered layout with the given name.
+func LookupLayout(name string) (*Layout, bool) {
+	layoutRegistry.RLock()
+	defer layoutRegistry.RUnlock()
+	layout, ok := layoutRegistry.layouts[name]
+	return layout, ok
+}
+
+// Layouts lists the names of the registered layouts in alphabetical order.
+func Layouts() []string {
+	layoutRegistry.RLock()
+	defer layoutRegistry.RUnlock()
+	names := make([]string, 0, len(layoutRegistry.layouts))
+	for name := range layoutRegistry.layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// layoutFields lists the groups of bits that every layout needs in order to decode the genes.
+var layoutFields = []string{"class", "region", "tag", "bodySkin", "pattern", "color"}
+
+// compile checks the fields of the layout and indexes the location of each of them, including the sub-fields.
+func (l *Layout) compile() error {
+	if l.Name == "" {
+		return fmt.Errorf("%w: missing name", ErrInvalidLayout)
+	}
+	if l.Size <= 0 || l.Size%64 != 0 {
+		return fmt.Errorf("%w: %s has a size of %d bits which is not a multiple of 64", ErrInvalidLayout, l.Name, l.Size)
+	}
+	ranges := map[string]binRange{}
+	var index func(prefix string, parent binRange, fields []Field) error
+	index = func(prefix string, parent binRange, fields []Field) error {
+		for _, f := range fields {
+			name := prefix + f.Name
+			r := binRange{parent.start + f.Offset, parent.start + f.Offset + f.Width}
+			if f.Name == "" || f.Offset < 0 || f.Width <= 0 || r.end > parent.end {
+				return fmt.Errorf("%w: %s field %q is out of range", ErrInvalidLayout, l.Name, name)
+			}
+			if _, ok := ranges[name]; ok {
+				return fmt.Errorf("%w: %s field %q is defined twice", ErrInvalidLayout, l.Name, name)
+			}
+			ranges[name] = r
+			if err := index(name+".", r, f.Fields); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := index("", binRange{0, l.Size}, l.Fields); err != nil {
+		return err
+	}
+	for _, f := range l.Fields {
+		// Each group of bits is read from the genes at once.
+		if f.Width > 64 {
+			return fmt.Errorf("%w: %s field %q exceeds 64 bits", ErrInvalidLayout, l.Name, f.Name)
+		}
+	}
+	require := func(name string) (binRange, error) {
+		r, ok := ranges[name]
+		if !ok {
+			return r, fmt.Errorf("%w: %s is missing field %q", ErrInvalidLayout, l.Name, name)
+		}
+		return r, nil
+	}
+	for _, name := range layoutFields {
+		if _, err := require(name); err != nil {
+			return err
+		}
+	}
+	parts := map[PartType]partRange{}
+	for _, partType := range []PartType{Eyes, Ears, Horn, Mouth, Back, Tail} {
+		partBin, err := require(string(partType))
+		if err != nil {
+			return err
+		}
+		// The ranges within a part are relative to the start of the part.
+		relative := func(name string) (binRange, error) {
+			r, err := require(fmt.Sprintf("%s.%s", partType, name))
+			return binRange{r.start - partBin.start, r.end - partBin.start}, err
+		}
+		var pr partRange
+		if pr.skin, err = relative("skin"); err != nil {
+			return err
+		}
+		for i, slot := range []string{"d", "r1", "r2"} {
+			pr.genes[i].slot = slot
+			if pr.genes[i].class, err = relative(slot + ".class"); err != nil {
+				return err
+			}
+			if pr.genes[i].part, err = relative(slot + ".part"); err != nil {
+				return err
+			}
+			if _, ok := ranges[fmt.Sprintf("%s.%s.skin", partType, slot)]; ok {
+				pr.genes[i].skin, _ = relative(slot + ".skin")
+			}
+		}
+		parts[partType] = pr
+	}
+	l.ranges = ranges
+	l.parts = parts
+	return nil
+}
+
+// registered checks that the layout was compiled by RegisterLayout.
+func (l *Layout) registered() error {
+	if l == nil || l.ranges == nil {
+		return fmt.Errorf("%w: layout is not registered", ErrInvalidLayout)
+	}
+	return nil
+}
+
+// readWords extracts the group of bits of the given field from the words of the genes.
+func (l *Layout) readWords(words []uint64, field string) Bin {
+	r, ok := l.ranges[field]
+	if !ok {
+		return Bin{}
+	}
+	return getWordBits(words, r.start, r.end)
+}
+
+// ParseHexLayout divide bits from the hex representation of the genes into their respective groups using the layout.
+// Fields of the layout that are not part of GeneBinGroup are ignored.
+func ParseHexLayout(hex string, layout *Layout) (GeneBinGroup, error) {
+	var gbg GeneBinGroup
+	if err := layout.registered(); err != nil {
+		return gbg, err
+	}
+	words := make([]uint64, layout.Size/64)
+	if err := parseWords(hex, words); err != nil {
+		return gbg, err
+	}
+	for _, f := range layout.Fields {
+		if bin := gbg.field(f.Name); bin != nil {
+			*bin = layout.readWords(words, f.Name).String()
+		}
+	}
+	return gbg, nil
+}
+
+// DecodeLayout parses the grouped binary of the given layout and extracts the Axie information into a Gene object.
+func DecodeLayout(gbg *GeneBinGroup, layout *Layout, opts ...DecodeOption) (Genes, error) {
+	if err := layout.registered(); err != nil {
+		return Genes{}, err
+	}
+	return decode(layoutGroup{gbg, layout}, newDecodeOptions(opts))
+}
+
+// ParseHexDecodeLayout parses the hex of the given layout into a Gene object. This combines ParseHexLayout and
+// DecodeLayout into a single function.
+func ParseHexDecodeLayout(hex string, layout *Layout, opts ...DecodeOption) (Genes, error) {
+	gbg, err := ParseHexLayout(hex, layout)
+	if err != nil {
+		return Genes{}, err
+	}
+	return DecodeLayout(&gbg, layout, opts...)
+}
+
+// layoutGroup is a grouped binary along with the layout that it was parsed from.
+type layoutGroup struct {
+	*GeneBinGroup
+	l *Layout
+}
+
+func (g layoutGroup) layout() *Layout {
+	return g.l
+}
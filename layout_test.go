@@ -0,0 +1,106 @@
+package agp
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// shiftLayout copies the fields of the layout into a new layout with the given number of unused bits at the start.
+func shiftLayout(name string, layout *Layout, offset int) *Layout {
+	fields := make([]Field, len(layout.Fields))
+	for i, f := range layout.Fields {
+		f.Offset += offset
+		fields[i] = f
+	}
+	return &Layout{Name: name, Size: layout.Size + offset, Fields: fields}
+}
+
+func TestRegisterLayout(t *testing.T) {
+	missingTail := shiftLayout("MISSING_TAIL", Layout256, 0)
+	missingTail.Fields = missingTail.Fields[:len(missingTail.Fields)-1]
+	missingSkin := shiftLayout("MISSING_SKIN", Layout256, 0)
+	missingSkin.Fields[7].Fields = missingSkin.Fields[7].Fields[1:]
+	outOfRange := shiftLayout("OUT_OF_RANGE", Layout256, 0)
+	outOfRange.Fields[0].Width = 300
+	tests := []struct {
+		name    string
+		layout  *Layout
+		wantErr bool
+	}{
+		{"VALID", shiftLayout("test-valid", Layout256, 64), false},
+		{"DUPLICATE_NAME", shiftLayout("256", Layout256, 0), true},
+		{"MISSING_NAME", shiftLayout("", Layout256, 0), true},
+		{"INVALID_SIZE", shiftLayout("INVALID_SIZE", Layout256, 8), true},
+		{"MISSING_TAIL", missingTail, true},
+		{"MISSING_SKIN", missingSkin, true},
+		{"OUT_OF_RANGE", outOfRange, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterLayout(tt.layout)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegisterLayout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidLayout) {
+				t.Fatalf("RegisterLayout() error = %v, want %v", err, ErrInvalidLayout)
+			}
+			if got, _ := LookupLayout(tt.layout.Name); (got == tt.layout) == tt.wantErr {
+				t.Fatalf("LookupLayout() got = %v, want registered %v", got, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseHexDecodeLayout(t *testing.T) {
+	layout256 := shiftLayout("test-256", Layout256, 64)
+	layout512 := shiftLayout("test-512", Layout512, 64)
+	for _, layout := range []*Layout{layout256, layout512} {
+		if err := RegisterLayout(layout); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hex256 := "0x11c642400a028ca14a428c20cc011080c61180a0820180604233082"
+	hex512 := "0x180000000000000001008040020c00000000000c106083040000000c086043020000000c2861830a0000000c1860c30a0000000c3061830c0000000c08604302"
+	tests := []struct {
+		name    string
+		hex     string
+		layout  *Layout
+		want    func(string, ...DecodeOption) (Genes, error)
+		wantErr bool
+	}{
+		{"SHIFTED_256", hex256, layout256, ParseHexDecode, false},
+		{"SHIFTED_512", hex512, layout512, ParseHexDecode512, false},
+		{"UNREGISTERED", hex256, shiftLayout("test-unregistered", Layout256, 64), ParseHexDecode, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The first 64 bits are not part of any field, so they are filled with ones to ensure that they are ignored.
+			digits, _ := padHex(tt.hex, tt.layout.Size-64)
+			hex := strings.Repeat("f", 16) + digits
+			got, err := ParseHexDecodeLayout(hex, tt.layout)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHexDecodeLayout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want, err := tt.want(tt.hex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("ParseHexDecodeLayout() got = %v, want %v", got, want)
+			}
+			gbg, _ := ParseHexLayout(hex, tt.layout)
+			formatted, err := FormatHexLayout(&gbg, tt.layout)
+			if err != nil {
+				t.Fatalf("FormatHexLayout() error = %v", err)
+			}
+			if roundTrip, _ := ParseHexLayout(formatted, tt.layout); roundTrip != gbg {
+				t.Fatalf("FormatHexLayout() got = %v, want %v", roundTrip, gbg)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package agp
+
+import "errors"
+
+// DecodeOption changes how the genes are decoded.
+type DecodeOption func(*decodeOptions)
+
+// decodeOptions holds the settings applied by the options given to the decode functions.
+type decodeOptions struct {
+	scorer  QualityScorer
+	strict  bool
+	lenient bool
+	// warnings collects the groups of bits that could not be decoded by a single call in lenient mode. Lenient decoding
+	// fails with ErrLenientDecoding when it is nil.
+	warnings *[]*DecodeError
+	// catalog is used to decode the genes instead of the catalog of the embedded files when set.
+	catalog *Catalog
+}
+
+// getCatalog finds the catalog used to decode the genes, which is the catalog of the embedded files unless another
+// catalog is given.
+func (o decodeOptions) getCatalog() (*Catalog, error) {
+	if o.catalog != nil {
+		return o.catalog, nil
+	}
+	return getCatalog()
+}
+
+// newDecodeOptions applies the given options on top of the default settings.
+func newDecodeOptions(opts []DecodeOption) decodeOptions {
+	if len(opts) == 0 {
+		// The settings are only allocated when options are given, so the default decoding stays free of allocations.
+		return decodeOptions{scorer: ClassQuality}
+	}
+	o := &decodeOptions{scorer: ClassQuality}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return *o
+}
+
+// WithQualityScorer sets the scorer used to compute the GeneQuality of the decoded genes. ClassQuality is used by
+// default, and when the scorer is nil.
+func WithQualityScorer(scorer QualityScorer) DecodeOption {
+	return func(o *decodeOptions) {
+		if scorer != nil {
+			o.scorer = scorer
+		}
+	}
+}
+
+// WithStrictValidation checks the decoded genes with Validate, failing with a *ValidationError when they break any of its
+// rules.
+func WithStrictValidation() DecodeOption {
+	return func(o *decodeOptions) {
+		o.strict = true
+	}
+}
+
+// WithLenientDecoding fills every field of the genes that can be decoded instead of failing on the first unknown group of
+// bits. Unknown part genes are replaced with placeholders holding their raw bits, and other unknown fields are left
+// empty. The groups of bits that could not be decoded are returned by DecodeLenient and ParseHexDecodeLenient, and by
+// DecodeBatch and DecodeStream in the Warnings of each result. Other decode functions would drop them, so they fail with
+// ErrLenientDecoding instead.
+func WithLenientDecoding() DecodeOption {
+	return func(o *decodeOptions) {
+		o.lenient = true
+	}
+}
+
+// warn adds a DecodeError to the warnings in lenient mode, in which case nil is returned. Other errors, and every error
+// when decoding is strict, are returned as is.
+func (o decodeOptions) warn(err error) error {
+	if err == nil || !o.lenient {
+		return err
+	}
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		return err
+	}
+	if o.warnings != nil {
+		*o.warnings = append(*o.warnings, decodeErr)
+	}
+	return nil
+}
+
+// WithCatalog decodes the genes using the given catalog instead of the catalog of the embedded files.
+func WithCatalog(catalog *Catalog) DecodeOption {
+	return func(o *decodeOptions) {
+		o.catalog = catalog
+	}
+}
@@ -0,0 +1,31 @@
+package agp
+
+import "fmt"
+
+// Parentage is the verdict of VerifyParentage. When the parents cannot have bred the child, Field names the first gene
+// of the child that neither parent could have passed down, such as "class" or "eyes.r1", and Gene holds its value.
+type Parentage struct {
+	Possible bool   `json:"possible"`
+	Field    string `json:"field,omitempty"`
+	Gene     string `json:"gene,omitempty"`
+}
+
+// VerifyParentage checks whether the child could have been bred from the given parents. The class of the child must be
+// the class of either parent, and each dominant and recessive gene of its parts must be one of the genes of the same
+// part of either parent. The skin of the genes is not inherited, so only their PartId is compared.
+func VerifyParentage(child, parentA, parentB Genes) Parentage {
+	if child.Class != parentA.Class && child.Class != parentB.Class {
+		return Parentage{Field: "class", Gene: string(child.Class)}
+	}
+	partsA, partsB := parentA.parts(), parentB.parts()
+	for i, p := range child.parts() {
+		odds := getGeneOdds(partsA[i].part, partsB[i].part)
+		for j, slot := range [3]string{"d", "r1", "r2"} {
+			gene := [3]PartGene{p.part.D, p.part.R1, p.part.R2}[j]
+			if getPartIdChance(odds, gene.PartId) == 0 {
+				return Parentage{Field: fmt.Sprintf("%s.%s", p.partType, slot), Gene: gene.PartId}
+			}
+		}
+	}
+	return Parentage{Possible: true}
+}
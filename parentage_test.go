@@ -0,0 +1,32 @@
+package agp
+
+import "testing"
+
+func TestVerifyParentage(t *testing.T) {
+	parentA, _ := ParseHexDecode("0xd34c44414a028c40023114400802082004130040025280200a0280a")
+	parentB, _ := ParseHexDecode("0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2")
+	child := parentA
+	child.Eyes.D, child.Eyes.R1 = parentB.Eyes.D, parentA.Eyes.R2
+	child.Tail.R2 = parentB.Tail.R1
+	child.Tail.R2.Skin = Mystic
+	wrongClass := child
+	wrongClass.Class = Bug
+	wrongPart := child
+	wrongPart.Horn.R1 = PartGene{PartId: "horn-dual-blade"}
+	tests := []struct {
+		name  string
+		child Genes
+		want  Parentage
+	}{
+		{"POSSIBLE", child, Parentage{Possible: true}},
+		{"IMPOSSIBLE_CLASS", wrongClass, Parentage{Field: "class", Gene: "bug"}},
+		{"IMPOSSIBLE_PART", wrongPart, Parentage{Field: "horn.r1", Gene: "horn-dual-blade"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyParentage(tt.child, parentA, parentB); got != tt.want {
+				t.Fatalf("VerifyParentage() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package agp
+
+import (
+	_ "embed"
+	"encoding/json"
+)
+
+//go:embed assets/patterns.json
+var patternsJson []byte
+
+// patternsJSON holds the content of the patterns.json file, keyed by the number of bits of a single pattern gene.
+type patternsJSON map[string]patternJSON
+
+// patternJSON describes how a pattern gene is split into the body shape and the pattern. The leading shapeBits bits
+// of the gene hold the body shape, and the remaining bits hold the pattern.
+type patternJSON struct {
+	ShapeBits int                  `json:"shapeBits"`
+	Shapes    map[string]BodyShape `json:"shapes"`
+}
+
+// getPatternsJSON unmarshalls the content of the patterns.json file into a patternsJSON object.
+func getPatternsJSON() (patternsJSON, error) {
+	var ret patternsJSON
+	err := json.Unmarshal(patternsJson, &ret)
+	return ret, err
+}
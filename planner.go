@@ -0,0 +1,217 @@
+package agp
+
+import "sort"
+
+// PlanOptions configures how PlanBreeding searches for breeding plans.
+type PlanOptions struct {
+	// Generations is the maximum number of generations bred to reach the build. Defaults to 1 when zero.
+	Generations int
+	// BreedCosts is the cost of breeding a parent for the first, second, third time and so on. Each breed costs the sum
+	// of the costs of both parents. Parents bred more times than listed use the last cost. Breeds are free when empty.
+	BreedCosts []float64
+	// MaxBreeds is the number of times each Axie can be bred. Defaults to 7 when zero.
+	MaxBreeds int
+	// BeamWidth is the number of offspring of each generation kept as parents of the next one. Defaults to 32 when zero.
+	BeamWidth int
+	// Limit is the number of plans returned. Defaults to 10 when zero.
+	Limit int
+}
+
+// withDefaults fills the unset options with their default values.
+func (opts PlanOptions) withDefaults() PlanOptions {
+	if opts.Generations <= 0 {
+		opts.Generations = 1
+	}
+	if opts.MaxBreeds <= 0 {
+		opts.MaxBreeds = 7
+	}
+	if opts.BeamWidth <= 0 {
+		opts.BeamWidth = 32
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+	return opts
+}
+
+// breedCost finds the cost of breeding a parent that was already bred the given number of times.
+func (opts PlanOptions) breedCost(breeds int) float64 {
+	if len(opts.BreedCosts) == 0 {
+		return 0
+	}
+	if breeds >= len(opts.BreedCosts) {
+		return opts.BreedCosts[len(opts.BreedCosts)-1]
+	}
+	return opts.BreedCosts[breeds]
+}
+
+// Plan is a sequence of breeds whose last offspring may have every dominant gene of a Build.
+type Plan struct {
+	Steps       []BreedStep `json:"steps"`
+	Probability float64     `json:"probability"`
+	Cost        float64     `json:"cost"`
+}
+
+// Breeds counts the breeds of the plan.
+func (p Plan) Breeds() int {
+	return len(p.Steps)
+}
+
+// BreedStep is a single breed of a Plan.
+type BreedStep struct {
+	ParentA PlanParent `json:"parentA"`
+	ParentB PlanParent `json:"parentB"`
+}
+
+// PlanParent refers to a parent of a BreedStep, which is either an Axie of the pool or the offspring of an earlier
+// step of the plan. The other index is -1.
+type PlanParent struct {
+	Pool int `json:"pool"`
+	Step int `json:"step"`
+}
+
+// planNode is an Axie of the pool or an offspring that may be bred in a plan.
+type planNode struct {
+	// carrier holds the chance of each gene of the Axie being the part of the build, following the order of Genes.parts.
+	carrier    [6][3]float64
+	pool       int
+	parents    [2]*planNode
+	generation int
+}
+
+// PlanBreeding searches for breeding plans of up to the given number of generations that produce an offspring having
+// every dominant gene of the build, starting from the Axies of the pool. Plans are ranked from the highest probability
+// of reaching the build, then from the fewest breeds, and then from the lowest cost.
+//
+// Offspring are not known until they are bred, so each gene of an offspring is tracked as the chance of it being the
+// part of the build. The probability of plans spanning more than one generation is therefore an estimate, which assumes
+// that the parents are unrelated. Offspring are never bred with their parents or siblings, but the lineage of the Axies
+// of the pool is not known.
+func PlanBreeding(pool []Genes, build Build, opts PlanOptions) []Plan {
+	opts = opts.withDefaults()
+	var inBuild [6]bool
+	nodes := make([]*planNode, len(pool))
+	for i, genes := range pool {
+		node := &planNode{pool: i}
+		for j, p := range genes.parts() {
+			partId, ok := build[p.partType]
+			if !ok {
+				continue
+			}
+			inBuild[j] = true
+			for k, gene := range [3]PartGene{p.part.D, p.part.R1, p.part.R2} {
+				if gene.PartId == partId {
+					node.carrier[j][k] = 1
+				}
+			}
+		}
+		nodes[i] = node
+	}
+	var plans []Plan
+	for generation := 1; generation <= opts.Generations; generation++ {
+		var offspring []*planNode
+		for i, a := range nodes {
+			for _, b := range nodes[i+1:] {
+				// Pairs of earlier generations were already bred.
+				if a.generation != generation-1 && b.generation != generation-1 || !canBreed(a, b) {
+					continue
+				}
+				child := breedNode(a, b, generation)
+				if plan, ok := newPlan(child, inBuild, opts); ok && plan.Probability > 0 {
+					plans = append(plans, plan)
+				}
+				offspring = append(offspring, child)
+			}
+		}
+		sort.SliceStable(offspring, func(i, j int) bool {
+			return offspring[i].inheritance(inBuild) > offspring[j].inheritance(inBuild)
+		})
+		if len(offspring) > opts.BeamWidth {
+			offspring = offspring[:opts.BeamWidth]
+		}
+		nodes = append(nodes, offspring...)
+	}
+	sort.SliceStable(plans, func(i, j int) bool {
+		if plans[i].Probability != plans[j].Probability {
+			return plans[i].Probability > plans[j].Probability
+		}
+		if plans[i].Breeds() != plans[j].Breeds() {
+			return plans[i].Breeds() < plans[j].Breeds()
+		}
+		return plans[i].Cost < plans[j].Cost
+	})
+	if len(plans) > opts.Limit {
+		plans = plans[:opts.Limit]
+	}
+	return plans
+}
+
+// canBreed checks that the Axies are neither the same, parent and offspring, nor siblings.
+func canBreed(a, b *planNode) bool {
+	if a == b || a.parents[0] == b || a.parents[1] == b || b.parents[0] == a || b.parents[1] == a {
+		return false
+	}
+	for _, parent := range a.parents {
+		if parent != nil && (parent == b.parents[0] || parent == b.parents[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// breedNode computes the chance of each gene of the offspring being the part of the build. Like getPartOdds, the
+// dominant and recessive genes of the offspring share the same chances.
+func breedNode(a, b *planNode, generation int) *planNode {
+	child := &planNode{pool: -1, parents: [2]*planNode{a, b}, generation: generation}
+	for i := range child.carrier {
+		chance := 0.0
+		for _, parent := range child.parents {
+			chance += parent.carrier[i][0]*dInheritance + parent.carrier[i][1]*r1Inheritance + parent.carrier[i][2]*r2Inheritance
+		}
+		child.carrier[i] = [3]float64{chance, chance, chance}
+	}
+	return child
+}
+
+// inheritance sums the chances of the Axie passing down each part of the build, which ranks the offspring kept as
+// parents of the next generation.
+func (n *planNode) inheritance(inBuild [6]bool) float64 {
+	inheritance := 0.0
+	for i, carrier := range n.carrier {
+		if inBuild[i] {
+			inheritance += carrier[0]*dInheritance + carrier[1]*r1Inheritance + carrier[2]*r2Inheritance
+		}
+	}
+	return inheritance
+}
+
+// newPlan lists the breeds leading to the offspring along with their cost. The plan is invalid when an Axie is bred
+// more times than allowed.
+func newPlan(child *planNode, inBuild [6]bool, opts PlanOptions) (Plan, bool) {
+	plan := Plan{Probability: 1}
+	for i, carrier := range child.carrier {
+		if inBuild[i] {
+			plan.Probability *= carrier[0]
+		}
+	}
+	breeds := map[PlanParent]int{}
+	valid := true
+	var walk func(n *planNode) PlanParent
+	walk = func(n *planNode) PlanParent {
+		if n.pool >= 0 {
+			return PlanParent{Pool: n.pool, Step: -1}
+		}
+		step := BreedStep{walk(n.parents[0]), walk(n.parents[1])}
+		for _, parent := range []PlanParent{step.ParentA, step.ParentB} {
+			plan.Cost += opts.breedCost(breeds[parent])
+			breeds[parent]++
+			if breeds[parent] > opts.MaxBreeds {
+				valid = false
+			}
+		}
+		plan.Steps = append(plan.Steps, step)
+		return PlanParent{Pool: -1, Step: len(plan.Steps) - 1}
+	}
+	walk(child)
+	return plan, valid
+}
@@ -0,0 +1,50 @@
+package agp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanBreeding(t *testing.T) {
+	mouth := Genes{Mouth: Part{D: PartGene{PartId: "mouth-nut-cracker"}}}
+	tail := Genes{Tail: Part{D: PartGene{PartId: "tail-nut-cracker"}}}
+	pool := []Genes{mouth, tail, {}}
+	build := Build{Mouth: "mouth-nut-cracker", Tail: "tail-nut-cracker"}
+	pooled := func(i int) PlanParent { return PlanParent{Pool: i, Step: -1} }
+	offspring := PlanParent{Pool: -1, Step: 0}
+	tests := []struct {
+		name string
+		opts PlanOptions
+		want []Plan
+	}{
+		{"SINGLE_GENERATION", PlanOptions{BreedCosts: []float64{100, 200}}, []Plan{
+			{[]BreedStep{{pooled(0), pooled(1)}}, 0.140625, 200},
+		}},
+		{"MULTIPLE_GENERATIONS", PlanOptions{Generations: 2, BreedCosts: []float64{100, 200}}, []Plan{
+			{[]BreedStep{{pooled(0), pooled(1)}}, 0.140625, 200},
+			{[]BreedStep{{pooled(1), pooled(2)}, {pooled(0), offspring}}, 0.0703125, 400},
+			{[]BreedStep{{pooled(0), pooled(2)}, {pooled(1), offspring}}, 0.0703125, 400},
+			{[]BreedStep{{pooled(0), pooled(1)}, {pooled(2), offspring}}, 0.03515625, 400},
+		}},
+		{"LIMIT", PlanOptions{Generations: 2, Limit: 2}, []Plan{
+			{[]BreedStep{{pooled(0), pooled(1)}}, 0.140625, 0},
+			{[]BreedStep{{pooled(1), pooled(2)}, {pooled(0), offspring}}, 0.0703125, 0},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlanBreeding(pool, build, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("PlanBreeding() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanBreedingChance(t *testing.T) {
+	genes, _ := ParseHexDecode("0xd34c44414a028c40023114400802082004130040025280200a0280a")
+	build := Build{Mouth: "mouth-nut-cracker", Back: "back-ronin", Tail: "tail-nut-cracker"}
+	plans := PlanBreeding([]Genes{genes, {}}, build, PlanOptions{})
+	if len(plans) != 1 || plans[0].Probability != BuildChance(genes, Genes{}, build) {
+		t.Fatalf("PlanBreeding() got = %v, want the chance of BuildChance() %v", plans, BuildChance(genes, Genes{}, build))
+	}
+}
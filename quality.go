@@ -0,0 +1,105 @@
+package agp
+
+import "math"
+
+// QualityScorer computes the quality of each part of an Axie. The gene quality of the Axie is the sum of the quality
+// of its parts.
+type QualityScorer interface {
+	// PartQuality computes the quality of a part of an Axie of the given class.
+	PartQuality(class Class, partType PartType, part Part) float64
+}
+
+// WeightedQuality scores the genes of a part that match the class of the Axie with the weight of their slot.
+type WeightedQuality struct {
+	D  float64 `json:"d"`
+	R1 float64 `json:"r1"`
+	R2 float64 `json:"r2"`
+}
+
+// Built-in scorers comparing the genes of each part to the class of the Axie.
+var (
+	// ClassQuality is the default scorer, which scores a pure Axie with 100 with most of the weight on the dominant genes.
+	ClassQuality QualityScorer = WeightedQuality{dQuality, r1Quality, r2Quality}
+	// PureCount counts the dominant genes that match the class of the Axie, from 0 to 6.
+	PureCount QualityScorer = WeightedQuality{1, 0, 0}
+	// DominantPurity scores the dominant genes only, from 0 to 100.
+	DominantPurity QualityScorer = WeightedQuality{100.0 / 6, 0, 0}
+	// BreedingQuality puts most of the weight on the recessive genes, which matter the most when breeding, from 0 to 100.
+	BreedingQuality QualityScorer = WeightedQuality{10.0 / 3, 20.0 / 3, 20.0 / 3}
+)
+
+// PartQuality sums the weights of the genes of the part that match the class of the Axie. An unknown class, which is
+// left empty by lenient decoding, never matches, so placeholders are not scored against an Axie of an unknown class.
+func (wq WeightedQuality) PartQuality(class Class, _ PartType, part Part) float64 {
+	if class == "" {
+		return 0
+	}
+	partQuality := 0.0
+	if part.D.Class == class {
+		partQuality += wq.D
+	}
+	if part.R1.Class == class {
+		partQuality += wq.R1
+	}
+	if part.R2.Class == class {
+		partQuality += wq.R2
+	}
+	return partQuality
+}
+
+// TargetQuality scores the genes of each part that match the target Build, regardless of the class of the Axie. Each
+// slot is weighted as it is by ClassQuality, and the target parts are scaled so that an Axie having all of them on
+// every slot scores 100. Part types without a target are ignored.
+type TargetQuality Build
+
+// PartQuality sums the weights of the genes of the part that match the target PartId of the part type.
+func (tq TargetQuality) PartQuality(_ Class, partType PartType, part Part) float64 {
+	partId, ok := tq[partType]
+	if !ok {
+		return 0
+	}
+	partQuality := 0.0
+	if part.D.PartId == partId {
+		partQuality += dQuality
+	}
+	if part.R1.PartId == partId {
+		partQuality += r1Quality
+	}
+	if part.R2.PartId == partId {
+		partQuality += r2Quality
+	}
+	return partQuality * 6 / float64(len(tq))
+}
+
+// Quality computes the gene quality of the Axie using the given scorer, or ClassQuality when the scorer is nil.
+func Quality(genes Genes, scorer QualityScorer) float64 {
+	if scorer == nil {
+		scorer = ClassQuality
+	}
+	return getGeneQuality(genes, scorer)
+}
+
+// QualityBreakdown computes the quality of each part of the Axie using the given scorer, or ClassQuality when the
+// scorer is nil.
+func QualityBreakdown(genes Genes, scorer QualityScorer) map[PartType]float64 {
+	if scorer == nil {
+		scorer = ClassQuality
+	}
+	breakdown := map[PartType]float64{}
+	for _, p := range genes.parts() {
+		breakdown[p.partType] = math.Round(scorer.PartQuality(genes.Class, p.partType, p.part)*100) / 100
+	}
+	return breakdown
+}
+
+// getGeneQuality computes the gene quality of the Axie.
+func getGeneQuality(genes Genes, scorer QualityScorer) float64 {
+	geneQuality := 0.0
+	geneQuality += scorer.PartQuality(genes.Class, Eyes, genes.Eyes)
+	geneQuality += scorer.PartQuality(genes.Class, Ears, genes.Ears)
+	geneQuality += scorer.PartQuality(genes.Class, Horn, genes.Horn)
+	geneQuality += scorer.PartQuality(genes.Class, Mouth, genes.Mouth)
+	geneQuality += scorer.PartQuality(genes.Class, Back, genes.Back)
+	geneQuality += scorer.PartQuality(genes.Class, Tail, genes.Tail)
+	return math.Round(geneQuality*100) / 100
+}
@@ -0,0 +1,77 @@
+package agp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuality(t *testing.T) {
+	mid := "0xd34c44414a028c40023114400802082004130040025280200a0280a"
+	high := "0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2"
+	tests := []struct {
+		name   string
+		hex    string
+		scorer QualityScorer
+		want   float64
+	}{
+		{"CLASS_QUALITY", mid, ClassQuality, 75.33},
+		{"PURE_COUNT", mid, PureCount, 5},
+		{"DOMINANT_PURITY", mid, DominantPurity, 83.33},
+		{"BREEDING_QUALITY", mid, BreedingQuality, 56.67},
+		{"TARGET_QUALITY", mid, TargetQuality{Mouth: "mouth-nut-cracker", Tail: "tail-nut-cracker"}, 88},
+		{"PURE_BREEDING_QUALITY", high, BreedingQuality, 100},
+		{"MISSING_TARGET", high, TargetQuality{Mouth: "mouth-nut-cracker"}, 0},
+		{"NIL_SCORER", mid, nil, 75.33},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genes, err := ParseHexDecode(tt.hex, WithQualityScorer(tt.scorer))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if genes.GeneQuality != tt.want {
+				t.Fatalf("ParseHexDecode() gene quality got = %v, want %v", genes.GeneQuality, tt.want)
+			}
+			if got := Quality(genes, tt.scorer); got != tt.want {
+				t.Fatalf("Quality() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQualityBreakdown(t *testing.T) {
+	genes, _ := ParseHexDecode("0xd34c44414a028c40023114400802082004130040025280200a0280a")
+	want := map[PartType]float64{Eyes: 3, Ears: 15.67, Horn: 13.67, Mouth: 12.67, Back: 13.67, Tail: 16.67}
+	if got := QualityBreakdown(genes, ClassQuality); !reflect.DeepEqual(got, want) {
+		t.Fatalf("QualityBreakdown() got = %v, want %v", got, want)
+	}
+}
+
+func TestLenientQuality(t *testing.T) {
+	unknownDominant, _ := ParseHex("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	for _, partBin := range []*string{&unknownDominant.Eyes, &unknownDominant.Ears, &unknownDominant.Horn, &unknownDominant.Mouth, &unknownDominant.Back, &unknownDominant.Tail} {
+		*partBin = (*partBin)[:2] + "1111" + (*partBin)[6:]
+	}
+	unknownClass := unknownDominant
+	unknownClass.Class = "1111"
+	tests := []struct {
+		name string
+		gbg  GeneBinGroup
+		want float64
+	}{
+		// Only the recessive beast genes are scored: the chubby, nut cracker and dual blade genes, and both jaguar genes.
+		{"UNKNOWN_DOMINANT_CLASSES", unknownDominant, 11},
+		{"UNKNOWN_CLASSES", unknownClass, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genes, _, err := DecodeLenient(&tt.gbg, Layout256)
+			if err != nil {
+				t.Fatalf("DecodeLenient() unexpected error = %v", err)
+			}
+			if genes.GeneQuality != tt.want {
+				t.Fatalf("DecodeLenient() gene quality got = %v, want %v", genes.GeneQuality, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package agp
+
+import "sort"
+
+// SimilarityWeights sets how much each part type counts towards the similarity of two Axies. Part types missing from
+// the weights are ignored.
+type SimilarityWeights map[PartType]float64
+
+// DefaultSimilarityWeights counts every part type equally.
+var DefaultSimilarityWeights = SimilarityWeights{Eyes: 1, Ears: 1, Horn: 1, Mouth: 1, Back: 1, Tail: 1}
+
+// Match is an Axie found by Nearest along with its similarity to the target.
+type Match struct {
+	Index      int     `json:"index"`
+	Genes      Genes   `json:"genes"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Similarity scores how alike the parts of two Axies are using DefaultSimilarityWeights, from 0 when no gene is
+// shared to 1 when every gene is the same.
+func Similarity(a, b Genes) float64 {
+	return SimilarityWeighted(a, b, DefaultSimilarityWeights)
+}
+
+// SimilarityWeighted scores how alike the parts of two Axies are using the given weights. Genes are compared slot by
+// slot, and each slot counts as much as it does towards the gene quality.
+func SimilarityWeighted(a, b Genes, weights SimilarityWeights) float64 {
+	similarity, total := 0.0, 0.0
+	partsA, partsB := a.parts(), b.parts()
+	for i, p := range partsA {
+		weight := weights[p.partType]
+		similarity += weight * getPartSimilarity(p.part, partsB[i].part)
+		total += weight
+	}
+	if total <= 0 {
+		return 0
+	}
+	return similarity / total
+}
+
+// getPartSimilarity scores how alike two parts are, from 0 to 1.
+func getPartSimilarity(partA, partB Part) float64 {
+	similarity := 0.0
+	if partA.D.PartId == partB.D.PartId {
+		similarity += dQuality
+	}
+	if partA.R1.PartId == partB.R1.PartId {
+		similarity += r1Quality
+	}
+	if partA.R2.PartId == partB.R2.PartId {
+		similarity += r2Quality
+	}
+	return similarity / (dQuality + r1Quality + r2Quality)
+}
+
+// Nearest finds the k Axies of the pool that are the most similar to the target, from the most to the least similar.
+// Axies with the same similarity keep their order in the pool. DefaultSimilarityWeights is used when the weights are
+// nil, and every Axie of the pool is returned when k is not positive.
+func Nearest(target Genes, pool []Genes, k int, weights SimilarityWeights) []Match {
+	if weights == nil {
+		weights = DefaultSimilarityWeights
+	}
+	matches := make([]Match, len(pool))
+	for i, genes := range pool {
+		matches[i] = Match{i, genes, SimilarityWeighted(target, genes, weights)}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Similarity > matches[j].Similarity })
+	if k > 0 && k < len(matches) {
+		matches = matches[:k]
+	}
+	return matches
+}
@@ -0,0 +1,53 @@
+package agp
+
+import (
+	"math"
+	"testing"
+)
+
+// partOf builds a part holding only the ids of its dominant and recessive genes.
+func partOf(d, r1, r2 string) Part {
+	return Part{D: PartGene{PartId: d}, R1: PartGene{PartId: r1}, R2: PartGene{PartId: r2}}
+}
+
+func TestSimilarity(t *testing.T) {
+	a := Genes{
+		Eyes: partOf("eyes-puppy", "eyes-zeal", "eyes-chubby"), Ears: partOf("ears-puppy", "ears-nyan", "ears-puppy"),
+		Horn: partOf("horn-imp", "horn-imp", "horn-dual-blade"), Mouth: partOf("mouth-goda", "mouth-nut-cracker", "mouth-goda"),
+		Back: partOf("back-ronin", "back-hero", "back-ronin"), Tail: partOf("tail-cottontail", "tail-rice", "tail-hare"),
+	}
+	recessive := a
+	recessive.Eyes = partOf("eyes-puppy", "eyes-puppy", "eyes-chubby")
+	dominant := a
+	dominant.Tail = partOf("tail-nut-cracker", "tail-rice", "tail-hare")
+	tests := []struct {
+		name    string
+		b       Genes
+		weights SimilarityWeights
+		want    float64
+	}{
+		{"SAME_GENES", a, DefaultSimilarityWeights, 1},
+		{"DIFFERENT_GENES", Genes{}, DefaultSimilarityWeights, 0},
+		{"RECESSIVE_DIFFERENCE", recessive, DefaultSimilarityWeights, 1 - 3.0/(76.0/6+4)/6},
+		{"DOMINANT_DIFFERENCE", dominant, DefaultSimilarityWeights, 1 - (76.0/6)/(76.0/6+4)/6},
+		{"WEIGHTED", dominant, SimilarityWeights{Eyes: 1, Tail: 1}, 1 - (76.0/6)/(76.0/6+4)/2},
+		{"NO_WEIGHTS", a, SimilarityWeights{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SimilarityWeighted(a, tt.b, tt.weights); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("SimilarityWeighted() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := Similarity(a, dominant); got != SimilarityWeighted(a, dominant, DefaultSimilarityWeights) {
+		t.Fatalf("Similarity() got = %v, want %v", got, SimilarityWeighted(a, dominant, DefaultSimilarityWeights))
+	}
+	matches := Nearest(a, []Genes{{}, dominant, a, recessive}, 2, nil)
+	if len(matches) != 2 || matches[0].Index != 2 || matches[1].Index != 3 {
+		t.Fatalf("Nearest() got = %v, want indexes [2 3]", matches)
+	}
+	if got := Nearest(a, []Genes{{}, a}, 0, nil); len(got) != 2 || got[0].Index != 1 {
+		t.Fatalf("Nearest() got = %v, want indexes [1 0]", got)
+	}
+}
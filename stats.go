@@ -0,0 +1,65 @@
+package agp
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+//go:embed assets/stats.json
+var statsJson []byte
+
+// statsJSON holds the content of the stats.json file.
+type statsJSON struct {
+	// Base holds the stats of each class before adding the bonuses of the parts.
+	Base map[Class]BattleStats `json:"base"`
+	// Parts holds the bonus stats given by each part, keyed by the class of its dominant gene.
+	Parts map[Class]BattleStats `json:"parts"`
+}
+
+// getStatsJSON unmarshalls the content of the stats.json file into a statsJSON object.
+func getStatsJSON() (statsJSON, error) {
+	var ret statsJSON
+	err := json.Unmarshal(statsJson, &ret)
+	return ret, err
+}
+
+// BattleStats holds the battle stats of an Axie.
+type BattleStats struct {
+	HP     int `json:"hp"`
+	Speed  int `json:"speed"`
+	Skill  int `json:"skill"`
+	Morale int `json:"morale"`
+}
+
+// add sums up the stats.
+func (s BattleStats) add(other BattleStats) BattleStats {
+	return BattleStats{s.HP + other.HP, s.Speed + other.Speed, s.Skill + other.Skill, s.Morale + other.Morale}
+}
+
+// Stats computes the battle stats of an Axie from the base stats of its class and the bonus stats of the class of
+// each of its dominant part genes.
+func Stats(genes Genes) (BattleStats, error) {
+	c, err := getCatalog()
+	if err != nil {
+		return BattleStats{}, err
+	}
+	return c.Stats(genes)
+}
+
+// Stats computes the battle stats of an Axie as done by Stats, using the stats of the catalog.
+func (c *Catalog) Stats(genes Genes) (BattleStats, error) {
+	stats, ok := c.stats.Base[genes.Class]
+	if !ok {
+		return BattleStats{}, errors.New(fmt.Sprint("no base stats for class:", genes.Class))
+	}
+	for _, part := range []Part{genes.Eyes, genes.Ears, genes.Horn, genes.Mouth, genes.Back, genes.Tail} {
+		bonus, ok := c.stats.Parts[part.D.Class]
+		if !ok {
+			return BattleStats{}, errors.New(fmt.Sprint("no part stats for class:", part.D.Class))
+		}
+		stats = stats.add(bonus)
+	}
+	return stats, nil
+}
@@ -0,0 +1,32 @@
+package agp
+
+import (
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	mixed, _ := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	pure, _ := ParseHexDecode("0x30000000041040230c4310c40c2308c20ca330ca0c6318ca0cc330cc0c2308c2")
+	tests := []struct {
+		name    string
+		genes   Genes
+		want    BattleStats
+		wantErr bool
+	}{
+		{"MIXED", mixed, BattleStats{41, 40, 31, 52}, false},
+		{"PURE", pure, BattleStats{61, 31, 31, 41}, false},
+		{"INVALID_CLASS", Genes{Class: "dragon"}, BattleStats{}, true},
+		{"INVALID_PART", Genes{Class: Beast}, BattleStats{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Stats(tt.genes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Stats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Stats() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
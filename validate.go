@@ -0,0 +1,102 @@
+package agp
+
+import "fmt"
+
+// Violation describes a rule broken by the genes. Field names the gene breaking the rule, such as "region" or "eyes.r1".
+type Violation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s", v.Field, v.Reason)
+}
+
+// Validate checks that the genes are consistent with each other and with the parts.json file. Every part gene must be
+// a known part of its part type and class, it must be the variant of the part that its skin decodes into, only the
+// japan skin may fall back to the global variant, the mystic flag of each part must match the skin of its dominant
+// gene, japan parts and skins require the japan region, and bionic parts require the agamogenesis tag. All violations are reported at once as a *ValidationError.
+func Validate(genes Genes) error {
+	c, err := getCatalog()
+	if err != nil {
+		return err
+	}
+	return c.Validate(genes)
+}
+
+// Validate checks the genes as done by Validate, against the parts of the catalog.
+func (c *Catalog) Validate(genes Genes) error {
+	var violations []Violation
+	add := func(field, reason string, args ...interface{}) {
+		violations = append(violations, Violation{field, fmt.Sprintf(reason, args...)})
+	}
+	if !isClass(genes.Class) {
+		add("class", "is unknown: %q", genes.Class)
+	}
+	if genes.Region != Global && genes.Region != Japan {
+		add("region", "is unknown: %q", genes.Region)
+	}
+	for _, p := range genes.parts() {
+		if p.part.Mystic != (p.part.D.Skin == Mystic) {
+			add(string(p.partType), "has a mystic flag of %t with a %s dominant gene", p.part.Mystic, p.part.D.Skin)
+		}
+		for i, slot := range [3]string{"d", "r1", "r2"} {
+			gene := [3]PartGene{p.part.D, p.part.R1, p.part.R2}[i]
+			field := fmt.Sprintf("%s.%s", p.partType, slot)
+			partGene, ok := c.parts[gene.PartId]
+			if !ok {
+				add(field, "is an unknown part: %q", gene.PartId)
+				continue
+			}
+			if partGene.Type != p.partType {
+				add(field, "is a part of the %s", partGene.Type)
+			}
+			if gene.Class != partGene.Class {
+				add(field, "is a %s part but has the %s class", partGene.Class, gene.Class)
+			}
+			// Genes without a skin were not decoded, so they may be any variant of the part. Only the japan skin falls back
+			// to the global variant, since most parts of a japan Axie have no japan variant.
+			if gene.Skin != "" && !isPartSkin(gene.Skin) {
+				add(field, "has an unknown skin: %q", gene.Skin)
+			} else if names, ok := c.variantIndex[gene.PartId]; ok && gene.Skin != "" {
+				if variantId := getPartId(partGene.Type, resolvePartName(names, gene.Skin)); variantId != gene.PartId {
+					add(field, "has the %s skin but is not %s", gene.Skin, variantId)
+				} else if gene.Skin != GlobalSkin && gene.Skin != JapanSkin && names[variantSkin(gene.Skin)] == "" {
+					add(field, "has the %s skin but %s has no such variant", gene.Skin, gene.PartId)
+				}
+			}
+			if partGene.SpecialGenes == "japan" && genes.Region != Japan {
+				add(field, "is a japan part in the %s region", genes.Region)
+			} else if gene.Skin == JapanSkin && genes.Region != Japan {
+				add(field, "has the japan skin in the %s region", genes.Region)
+			}
+			if partGene.SpecialGenes == "bionic" && genes.Tag != Agamogenesis {
+				add(field, "is a bionic part without the agamogenesis tag")
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &ValidationError{violations}
+	}
+	return nil
+}
+
+// isClass checks whether the class is one that can be decoded.
+func isClass(class Class) bool {
+	for _, c := range binClassMap {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// isPartSkin checks whether the skin is one that can be decoded.
+func isPartSkin(skin PartSkin) bool {
+	for _, s := range binPartSkinMap {
+		if s == skin {
+			return true
+		}
+	}
+	return false
+}
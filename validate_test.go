@@ -0,0 +1,78 @@
+package agp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	c, _ := DefaultCatalog()
+	genes, _ := ParseHexDecode("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	japan := genes
+	japan.Back.R1, _ = c.getPartGeneByName(Back, "Hamaya")
+	japan.Back.R1.Skin = JapanSkin
+	bionic := genes
+	bionic.Horn.D, _ = c.getPartGeneByName(Horn, "5H04L-5T4R")
+	bionic.Horn.D.Skin = Bionic
+	mystic := genes
+	mystic.Eyes.Mystic = true
+	mystic.Eyes.D.Skin = Mystic
+	japanSkin := genes
+	japanSkin.Eyes.R1.Skin = JapanSkin
+	variant := genes
+	variant.Eyes.Mystic = true
+	variant.Eyes.D, _ = c.getPartGeneByName(Eyes, "Zeal")
+	variant.Eyes.D.Skin = Mystic
+	japanRegion, _ := ParseHexDecode("0x00080000011c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	agamogenesis, _ := ParseHexDecode512("0x180000000000000001008040020c00000000008c086083040000000c086043020000000c2861830a0000000c1860c30a0000018c3061830c0000000c08604302")
+	unknown := genes
+	unknown.Class = "unknown"
+	unknown.Tail.R2 = PartGene{PartId: "tail-unknown"}
+	mismatch := genes
+	mismatch.Mouth.D.Class = Bird
+	mismatch.Ears.R1 = genes.Eyes.R1
+	tests := []struct {
+		name  string
+		genes Genes
+		want  []Violation
+	}{
+		{"VALID", genes, nil},
+		{"JAPAN_PART", japan, []Violation{{"back.r1", "is a japan part in the global region"}}},
+		{"BIONIC_PART", bionic, []Violation{{"horn.d", "is a bionic part without the agamogenesis tag"}}},
+		{"MISSING_MYSTIC_VARIANT", mystic, []Violation{{"eyes.d", "has the mystic skin but eyes-chubby has no such variant"}}},
+		{"JAPAN_SKIN", japanSkin, []Violation{{"eyes.r1", "has the japan skin in the global region"}}},
+		{"JAPAN", japanRegion, nil},
+		{"JAPAN_MYSTIC_AGAMOGENESIS", agamogenesis, nil},
+		{"WRONG_VARIANT", variant, []Violation{{"eyes.d", "has the mystic skin but is not eyes-calico-zeal"}}},
+		{"UNKNOWN_VALUES", unknown, []Violation{{"class", `is unknown: "unknown"`}, {"tail.r2", `is an unknown part: "tail-unknown"`}}},
+		{"MISMATCHED_PARTS", mismatch, []Violation{{"ears.r1", "is a part of the eyes"}, {"mouth.d", "is a reptile part but has the bird class"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.genes)
+			var validationErr *ValidationError
+			if (err != nil) != (tt.want != nil) || err != nil && !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.want)
+			}
+			if err != nil && !reflect.DeepEqual(validationErr.Violations, tt.want) {
+				t.Fatalf("Validate() got = %v, want %v", validationErr.Violations, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrictValidation(t *testing.T) {
+	gbg, _ := ParseHex("0x11c642400a028ca14a428c20cc011080c61180a0820180604233082")
+	// The bionic skin turns the parasite horn into its bionic variant, which the 256 bit format has no tag for.
+	gbg.Horn = "01" + "0001" + "001010" + gbg.Horn[12:]
+	if _, err := Decode(&gbg); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if _, err := Decode(&gbg, WithStrictValidation()); !errors.Is(err, ErrInvalidGenes) {
+		t.Fatalf("Decode() error = %v, want %v", err, ErrInvalidGenes)
+	}
+	if _, err := ParseHexDecode("0x00080000011c642400a028ca14a428c20cc011080c61180a0820180604233082", WithStrictValidation()); err != nil {
+		t.Fatalf("ParseHexDecode() error = %v", err)
+	}
+}